@@ -144,7 +144,7 @@ type ActivitiesCreateOptions struct {
 	DueTime      string      `json:"due_time,omitempty"`
 	Duration     string      `json:"duration,omitempty"`
 	UserID       uint        `json:"user_id,omitempty"`
-	DealID       uint        `json:"user_id,omitempty"`
+	DealID       uint        `json:"deal_id,omitempty"`
 	PersonID     uint        `json:"person_id,omitempty"`
 	Participants interface{} `json:"participants,omitempty"`
 	OrgID        uint        `json:"org_id,omitempty"`
@@ -155,7 +155,7 @@ type ActivitiesCreateOptions struct {
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Activities/put_activities_id
 func (s *ActivitiesService) Update(ctx context.Context, id int, opt *ActivitiesCreateOptions) (*ActivityResponse, *Response, error) {
 	uri := fmt.Sprintf("/activities/%v", id)
-	req, err := s.client.NewRequest(http.MethodPut, uri, opt, nil)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
 
 	if err != nil {
 		return nil, nil, err
@@ -12,35 +12,10 @@ import (
 type ActivityFieldsService service
 
 // ActivityField represents a Pipedrive activity field.
-type ActivityField struct {
-	ID                 int         `json:"id"`
-	Key                string      `json:"key"`
-	Name               string      `json:"name"`
-	OrderNr            int         `json:"order_nr"`
-	PicklistData       interface{} `json:"picklist_data,omitempty"`
-	FieldType          string      `json:"field_type"`
-	AddTime            string      `json:"add_time"`
-	UpdateTime         string      `json:"update_time"`
-	ActiveFlag         bool        `json:"active_flag"`
-	EditFlag           bool        `json:"edit_flag"`
-	IndexVisibleFlag   bool        `json:"index_visible_flag"`
-	DetailsVisibleFlag bool        `json:"details_visible_flag"`
-	AddVisibleFlag     bool        `json:"add_visible_flag"`
-	ImportantFlag      bool        `json:"important_flag"`
-	BulkEditAllowed    bool        `json:"bulk_edit_allowed"`
-	MandatoryFlag      bool        `json:"mandatory_flag"`
-	Options            []struct {
-		ID    string `json:"id"`
-		Label string `json:"label"`
-	} `json:"options,omitempty"`
-}
+type ActivityField = EntityField
 
 // ActivityFieldsResponse represents multiple activity fields response.
-type ActivityFieldsResponse struct {
-	Success        bool            `json:"success"`
-	Data           []ActivityField `json:"data"`
-	AdditionalData AdditionalData  `json:"additional_data"`
-}
+type ActivityFieldsResponse = EntityFieldsResponse
 
 // List returns all fields for activity.
 //
@@ -62,3 +37,32 @@ func (s *ActivityFieldsService) List(ctx context.Context) (*ActivityFieldsRespon
 
 	return record, resp, nil
 }
+
+// ListFields returns all activity fields. It implements FieldsAPI.
+func (s *ActivityFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_ACTIVITY)
+}
+
+// GetField returns the activity field with the given ID. It implements
+// FieldsAPI.
+func (s *ActivityFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_ACTIVITY, id)
+}
+
+// CreateField fails, as activity fields cannot be edited. It implements
+// FieldsAPI.
+func (s *ActivityFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_ACTIVITY, opt)
+}
+
+// UpdateField fails, as activity fields cannot be edited. It implements
+// FieldsAPI.
+func (s *ActivityFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_ACTIVITY, id, opt)
+}
+
+// DeleteField fails, as activity fields cannot be edited. It implements
+// FieldsAPI.
+func (s *ActivityFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_ACTIVITY, id)
+}
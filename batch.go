@@ -0,0 +1,481 @@
+package pipedrive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Type of batch operations.
+type BatchOperationType string
+
+const (
+	BATCH_CREATE BatchOperationType = "create"
+	BATCH_UPDATE BatchOperationType = "update"
+	BATCH_DELETE BatchOperationType = "delete"
+)
+
+// BatchOperation creates, updates or deletes a record.
+type BatchOperation struct {
+	// Key identifies the operation in reports, for a run to be resumed.
+	// It defaults to the position of the operation in the stream, starting
+	// at 1, which only identifies it if the stream is replayed in order.
+	Key string
+
+	Type   BatchOperationType
+	Object EventObject
+
+	// ID of the record updated or deleted.
+	ID int
+
+	// Data is the body of creates and updates, such as a
+	// *DealCreateOptions, a *DealsUpdateOptions or a map.
+	Data interface{}
+}
+
+// Status of batch results.
+type BatchStatus string
+
+const (
+	BATCH_OK     BatchStatus = "ok"
+	BATCH_FAILED BatchStatus = "failed"
+	// The operation completed in a previous run, or deleted a record that
+	// no longer existed.
+	BATCH_SKIPPED BatchStatus = "skipped"
+	// The run was cancelled while the operation was in flight, it may or
+	// may not have reached the API.
+	BATCH_ABORTED BatchStatus = "aborted"
+)
+
+// Kinds of batch errors.
+const (
+	BatchErrorRateLimit  = "rate_limit"
+	BatchErrorClient     = "client"
+	BatchErrorServer     = "server"
+	BatchErrorNetwork    = "network"
+	BatchErrorValidation = "validation"
+)
+
+// BatchResult reports the outcome of a batch operation.
+type BatchResult struct {
+	Key    string             `json:"key"`
+	Type   BatchOperationType `json:"type"`
+	Object EventObject        `json:"object"`
+
+	// ID of the record, the one created for creates.
+	ID int `json:"id,omitempty"`
+
+	Status   BatchStatus `json:"status"`
+	Attempts int         `json:"attempts,omitempty"`
+
+	// ErrorKind classifies the error of failed operations, such as
+	// BatchErrorClient for a 4xx response or BatchErrorValidation for an
+	// operation that could not be sent.
+	ErrorKind  string `json:"error_kind,omitempty"`
+	Error      string `json:"error,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+
+	// Err is the error of the last attempt, such as an *ErrorResponse or
+	// a *RateLimitError. It is not part of reports.
+	Err error `json:"-"`
+}
+
+func (r BatchResult) String() string {
+	return Stringify(r)
+}
+
+// BatchSummary counts the results of a run.
+type BatchSummary struct {
+	OK      int `json:"ok"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Aborted int `json:"aborted"`
+}
+
+func (s BatchSummary) String() string {
+	return Stringify(s)
+}
+
+// BatchRunner runs create, update and delete operations concurrently,
+// waiting for the rate limit to reset when exhausted and retrying
+// transient errors. Creates are only retried when rate limited: a create
+// failing otherwise may have reached the API, and retrying it could create
+// the record twice.
+type BatchRunner struct {
+	client    *Client
+	completed map[string]bool
+
+	// Concurrency is the number of operations run at once. It defaults
+	// to 4.
+	Concurrency int
+
+	// MaxAttempts is the number of times an operation is tried before it
+	// fails. It defaults to 3.
+	MaxAttempts int
+
+	// Backoff is the delay before the second attempt, doubled on each
+	// attempt. It defaults to one second.
+	Backoff time.Duration
+
+	// OnResult, if set, is called with each result.
+	OnResult func(result BatchResult)
+}
+
+// NewBatchRunner returns a batch runner using the client.
+func (c *Client) NewBatchRunner() *BatchRunner {
+	return &BatchRunner{
+		client:    c,
+		completed: map[string]bool{},
+	}
+}
+
+// Resume reads the NDJSON report of a previous run. Operations it reports
+// as ok or skipped are skipped by the next run.
+func (r *BatchRunner) Resume(report io.Reader) error {
+	scanner := bufio.NewScanner(report)
+	scanner.Buffer(nil, 1<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var result BatchResult
+
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			return fmt.Errorf("report line %d: %v", line, err)
+		}
+
+		if result.Status == BATCH_OK || result.Status == BATCH_SKIPPED {
+			r.completed[result.Key] = true
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Run runs the operations received until operations is closed, writing
+// their results to report as NDJSON, in the order they complete. report
+// may be nil.
+//
+// Operations that fail do not stop the run, it returns early only when ctx
+// is done or report cannot be written. Operations in flight when it returns
+// early are reported as aborted: they are run again when resuming from the
+// report, as are those not reported, although an aborted create may have
+// created its record.
+func (r *BatchRunner) Run(ctx context.Context, operations <-chan BatchOperation, report io.Writer) (*BatchSummary, error) {
+	concurrency := r.Concurrency
+
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	summary := &BatchSummary{}
+
+	var (
+		mu       sync.Mutex
+		writeErr error
+		wg       sync.WaitGroup
+	)
+
+	encoder := json.NewEncoder(io.Discard)
+
+	if report != nil {
+		encoder = json.NewEncoder(report)
+	}
+
+	record := func(result BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch result.Status {
+		case BATCH_OK:
+			summary.OK++
+		case BATCH_FAILED:
+			summary.Failed++
+		case BATCH_SKIPPED:
+			summary.Skipped++
+		case BATCH_ABORTED:
+			summary.Aborted++
+		}
+
+		if err := encoder.Encode(result); err != nil && writeErr == nil {
+			writeErr = err
+			cancel()
+		}
+
+		if r.OnResult != nil {
+			r.OnResult(result)
+		}
+	}
+
+	jobs := make(chan BatchOperation)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for operation := range jobs {
+				result, _ := r.run(ctx, operation)
+				record(result)
+			}
+		}()
+	}
+
+	position := 0
+
+feed:
+	for {
+		select {
+		case <-ctx.Done():
+			break feed
+
+		case operation, ok := <-operations:
+			if !ok {
+				break feed
+			}
+
+			position++
+
+			if operation.Key == "" {
+				operation.Key = strconv.Itoa(position)
+			}
+
+			if r.completed[operation.Key] {
+				record(BatchResult{
+					Key:    operation.Key,
+					Type:   operation.Type,
+					Object: operation.Object,
+					ID:     operation.ID,
+					Status: BATCH_SKIPPED,
+				})
+				continue
+			}
+
+			select {
+			case jobs <- operation:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if writeErr != nil {
+		return summary, writeErr
+	}
+
+	return summary, ctx.Err()
+}
+
+// run runs an operation, retrying transient errors. It returns false, with
+// an aborted result, if ctx was done before the operation completed.
+func (r *BatchRunner) run(ctx context.Context, operation BatchOperation) (BatchResult, bool) {
+	result := BatchResult{
+		Key:    operation.Key,
+		Type:   operation.Type,
+		Object: operation.Object,
+		ID:     operation.ID,
+	}
+
+	attempts := r.MaxAttempts
+
+	if attempts < 1 {
+		attempts = 3
+	}
+
+	backoff := r.Backoff
+
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for {
+		if err := sleep(ctx, rateLimitWait(r.client.Rate())); err != nil {
+			return abortBatchResult(result, err), false
+		}
+
+		result.Attempts++
+
+		req, err := r.client.newBatchRequest(operation)
+
+		if err != nil {
+			result.Status = BATCH_FAILED
+			result.ErrorKind = BatchErrorValidation
+			result.Error = err.Error()
+			result.Err = err
+
+			return result, true
+		}
+
+		id, err := r.client.doBatchRequest(ctx, req)
+
+		if ctx.Err() != nil {
+			return abortBatchResult(result, ctx.Err()), false
+		}
+
+		if err == nil {
+			result.Status = BATCH_OK
+
+			if id != 0 {
+				result.ID = id
+			}
+
+			return result, true
+		}
+
+		kind, status, transient := classifyBatchError(err)
+
+		if operation.Type == BATCH_CREATE && kind != BatchErrorRateLimit {
+			transient = false
+		}
+
+		if operation.Type == BATCH_DELETE && status == http.StatusNotFound {
+			result.Status = BATCH_SKIPPED
+			return result, true
+		}
+
+		if !transient || result.Attempts >= attempts {
+			result.Status = BATCH_FAILED
+			result.ErrorKind = kind
+			result.Error = err.Error()
+			result.StatusCode = status
+			result.Err = err
+
+			return result, true
+		}
+
+		wait := backoff << (result.Attempts - 1)
+
+		var rateLimitError *RateLimitError
+
+		if errors.As(err, &rateLimitError) {
+			if reset := rateLimitWait(rateLimitError.Rate); reset > 0 {
+				wait = reset
+			}
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return abortBatchResult(result, err), false
+		}
+	}
+}
+
+// newBatchRequest returns the request of an operation.
+func (c *Client) newBatchRequest(operation BatchOperation) (*http.Request, error) {
+	path, err := collectionPath(operation.Object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Type {
+	case BATCH_CREATE:
+		return c.NewRequest(http.MethodPost, path, nil, operation.Data)
+	case BATCH_UPDATE:
+		return c.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", path, operation.ID), nil, operation.Data)
+	case BATCH_DELETE:
+		return c.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", path, operation.ID), nil, nil)
+	}
+
+	return nil, fmt.Errorf("unknown batch operation type %q", operation.Type)
+}
+
+// doBatchRequest sends the request of an operation and returns the ID of
+// the record created.
+func (c *Client) doBatchRequest(ctx context.Context, req *http.Request) (int, error) {
+	var record struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+
+	if _, err := c.Do(ctx, req, &record); err != nil {
+		return 0, err
+	}
+
+	return record.Data.ID, nil
+}
+
+// classifyBatchError returns the kind of an error, the status code of the
+// response if any, and whether the operation may succeed if retried.
+func classifyBatchError(err error) (string, int, bool) {
+	var rateLimitError *RateLimitError
+
+	if errors.As(err, &rateLimitError) {
+		return BatchErrorRateLimit, http.StatusForbidden, true
+	}
+
+	var errorResponse *ErrorResponse
+
+	if errors.As(err, &errorResponse) {
+		status := errorResponse.Response.StatusCode
+
+		switch {
+		case status == http.StatusTooManyRequests:
+			return BatchErrorRateLimit, status, true
+		case status >= 500:
+			return BatchErrorServer, status, true
+		default:
+			return BatchErrorClient, status, false
+		}
+	}
+
+	return BatchErrorNetwork, 0, true
+}
+
+// rateLimitWait returns how long to wait for rate to allow requests again.
+func rateLimitWait(rate Rate) time.Duration {
+	if rate.Remaining > 0 || rate.Reset.IsZero() {
+		return 0
+	}
+
+	// The reset header holds the seconds left until the reset, which is
+	// parsed as a time close to the epoch.
+	if seconds := rate.Reset.Unix(); seconds < 24*60*60 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	return time.Until(rate.Reset.Time)
+}
+
+// abortBatchResult marks result aborted by err, the error of a done
+// context.
+func abortBatchResult(result BatchResult, err error) BatchResult {
+	result.Status = BATCH_ABORTED
+	result.Error = err.Error()
+	result.Err = err
+
+	return result
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package pipedrive
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchRunner_Run(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		count := requests[r.Method+" "+r.URL.Path]
+		mu.Unlock()
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/deals":
+			w.Write([]byte(`{"success": true, "data": {"id": 10}}`))
+		case "PUT /v1/deals/1":
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		case "PUT /v1/deals/2":
+			if count == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"success": true, "data": {"id": 2}}`))
+		case "DELETE /v1/deals/3":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "error": "Deal not found"}`))
+		case "PUT /v1/deals/4":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "error": "Invalid stage"}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+
+	operations := []BatchOperation{
+		{Type: BATCH_CREATE, Object: OBJECT_DEAL, Data: &DealCreateOptions{Title: "New"}},
+		{Type: BATCH_UPDATE, Object: OBJECT_DEAL, ID: 1, Data: &DealsUpdateOptions{Title: "One"}},
+		{Type: BATCH_UPDATE, Object: OBJECT_DEAL, ID: 2, Data: &DealsUpdateOptions{Title: "Two"}},
+		{Type: BATCH_DELETE, Object: OBJECT_DEAL, ID: 3},
+		{Type: BATCH_UPDATE, Object: OBJECT_DEAL, ID: 4, Data: &DealsUpdateOptions{StageID: 99}},
+	}
+
+	run := func(runner *BatchRunner, report *bytes.Buffer) (*BatchSummary, map[string]BatchResult) {
+		results := map[string]BatchResult{}
+		runner.Backoff = time.Millisecond
+		runner.OnResult = func(result BatchResult) {
+			results[result.Key] = result
+		}
+
+		stream := make(chan BatchOperation)
+
+		go func() {
+			for _, operation := range operations {
+				stream <- operation
+			}
+			close(stream)
+		}()
+
+		summary, err := runner.Run(context.Background(), stream, report)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return summary, results
+	}
+
+	var report bytes.Buffer
+
+	summary, results := run(client.NewBatchRunner(), &report)
+
+	if *summary != (BatchSummary{OK: 3, Failed: 1, Skipped: 1}) {
+		t.Errorf("Got summary %v", summary)
+	}
+
+	if results["1"].ID != 10 || results["3"].Attempts != 2 || results["4"].Status != BATCH_SKIPPED {
+		t.Errorf("Got results %v", results)
+	}
+
+	if failed := results["5"]; failed.Status != BATCH_FAILED || failed.ErrorKind != BatchErrorClient || failed.StatusCode != http.StatusBadRequest {
+		t.Errorf("Got failed result %v", failed)
+	}
+
+	runner := client.NewBatchRunner()
+
+	if err := runner.Resume(bytes.NewReader(report.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, _ = run(runner, &bytes.Buffer{})
+
+	if *summary != (BatchSummary{Failed: 1, Skipped: 4}) {
+		t.Errorf("Got summary %v on resume", summary)
+	}
+
+	if requests["PUT /v1/deals/4"] != 2 || requests["PUT /v1/deals/1"] != 1 {
+		t.Errorf("Got requests %v", requests)
+	}
+}
+
+func TestBatchRunner_RunCreate(t *testing.T) {
+	var mu sync.Mutex
+	posts := 0
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		posts++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	runner := client.NewBatchRunner()
+	runner.Backoff = time.Millisecond
+
+	result, _ := runner.run(context.Background(), BatchOperation{Type: BATCH_CREATE, Object: OBJECT_DEAL, Data: &DealCreateOptions{Title: "New"}})
+
+	if result.Status != BATCH_FAILED || result.ErrorKind != BatchErrorServer || result.Attempts != 1 {
+		t.Errorf("Got result %v", result)
+	}
+
+	if posts != 1 {
+		t.Errorf("Got %d POST requests, want 1", posts)
+	}
+}
+
+func TestBatchRunner_RunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	}))
+
+	operations := make(chan BatchOperation, 1)
+	operations <- BatchOperation{Key: "new", Type: BATCH_CREATE, Object: OBJECT_DEAL, Data: &DealCreateOptions{Title: "New"}}
+
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	var report bytes.Buffer
+
+	summary, err := client.NewBatchRunner().Run(ctx, operations, &report)
+
+	if err != context.Canceled {
+		t.Errorf("Got error %v, want %v", err, context.Canceled)
+	}
+
+	if *summary != (BatchSummary{Aborted: 1}) {
+		t.Errorf("Got summary %v", summary)
+	}
+
+	if !bytes.Contains(report.Bytes(), []byte(`"status":"aborted"`)) {
+		t.Errorf("Got report %s", report.String())
+	}
+
+	runner := client.NewBatchRunner()
+
+	if err := runner.Resume(&report); err != nil {
+		t.Fatal(err)
+	}
+
+	if runner.completed["new"] {
+		t.Error("Got an aborted operation completed on resume")
+	}
+}
@@ -0,0 +1,256 @@
+package pipedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// bulkDeleteObjects are the objects whose records can be deleted in bulk.
+var bulkDeleteObjects = map[EventObject]bool{
+	OBJECT_ACTIVITY:     true,
+	OBJECT_DEAL:         true,
+	OBJECT_ORGANIZATION: true,
+	OBJECT_PERSON:       true,
+	OBJECT_STAGE:        true,
+}
+
+// fieldCollections maps objects to the path of the collection endpoint of
+// their fields.
+var fieldCollections = map[EventObject]string{
+	OBJECT_DEAL:         "/dealFields",
+	OBJECT_ORGANIZATION: "/organizationFields",
+	OBJECT_PERSON:       "/personFields",
+	OBJECT_PRODUCT:      "/productFields",
+}
+
+// BulkDeleteOptions specifices the optional parameters to the
+// Client.BulkDelete method.
+type BulkDeleteOptions struct {
+	// Fields deletes fields of the object rather than records.
+	Fields bool
+
+	// ChunkSize is the number of IDs deleted per request. It defaults to
+	// 100.
+	ChunkSize int
+
+	// Concurrency is the number of requests sent at once. It defaults to 4.
+	Concurrency int
+}
+
+// BulkDeleteFailure reports an ID that could not be deleted.
+type BulkDeleteFailure struct {
+	ID    int    `json:"id"`
+	Error string `json:"error"`
+
+	Err error `json:"-"`
+}
+
+// BulkDeleteResult reports the IDs deleted by a bulk delete.
+type BulkDeleteResult struct {
+	// Deleted lists the IDs the API reported deleted.
+	Deleted []int `json:"deleted"`
+
+	// Verified lists the IDs the API did not report deleted, but found to
+	// be deleted or to not exist.
+	Verified []int `json:"verified,omitempty"`
+
+	// Failed lists the IDs that still exist, or whose deletion could not
+	// be verified.
+	Failed []BulkDeleteFailure `json:"failed,omitempty"`
+}
+
+func (r BulkDeleteResult) String() string {
+	return Stringify(r)
+}
+
+// BulkDelete deletes the records of object with the given IDs, or its
+// fields if opt.Fields is set. The IDs are deleted in chunks sent
+// concurrently, keeping URLs short. IDs missing from the IDs each chunk
+// reports deleted are fetched to verify they are gone. The IDs of chunks
+// whose request fails are reported failed without verification, and can
+// be passed to BulkDelete again.
+//
+// A *BulkDeleteError is returned along with the result if any ID could not
+// be deleted. Deals, persons, organizations, activities and stages, and
+// the fields of deals, persons, organizations and products can be deleted.
+func (c *Client) BulkDelete(ctx context.Context, object EventObject, ids []int, opt *BulkDeleteOptions) (*BulkDeleteResult, error) {
+	if opt == nil {
+		opt = &BulkDeleteOptions{}
+	}
+
+	var path string
+
+	if opt.Fields {
+		path = fieldCollections[object]
+	} else if bulkDeleteObjects[object] {
+		path = objectCollections[object]
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("%q cannot be deleted in bulk", object)
+	}
+
+	size := opt.ChunkSize
+
+	if size < 1 {
+		size = 100
+	}
+
+	concurrency := opt.Concurrency
+
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	result := &BulkDeleteResult{}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		chunk := ids[start:end]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return result, ctx.Err()
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			deleted, verified, failed := c.deleteChunk(ctx, path, chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			result.Deleted = append(result.Deleted, deleted...)
+			result.Verified = append(result.Verified, verified...)
+			result.Failed = append(result.Failed, failed...)
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Ints(result.Deleted)
+	sort.Ints(result.Verified)
+	sort.Slice(result.Failed, func(i, j int) bool {
+		return result.Failed[i].ID < result.Failed[j].ID
+	})
+
+	if len(result.Failed) > 0 {
+		failed := make([]int, len(result.Failed))
+
+		for i, failure := range result.Failed {
+			failed[i] = failure.ID
+		}
+
+		return result, &BulkDeleteError{Object: object, IDs: failed}
+	}
+
+	return result, nil
+}
+
+// deleteChunk deletes the IDs of a chunk and verifies the IDs not reported
+// deleted by a successful request.
+func (c *Client) deleteChunk(ctx context.Context, path string, ids []int) (deleted, verified []int, failed []BulkDeleteFailure) {
+	req, err := c.NewRequest(http.MethodDelete, path, &DeleteMultipleOptions{
+		Ids: arrayToString(ids, ","),
+	}, nil)
+
+	var record struct {
+		Data struct {
+			ID []int `json:"id"`
+		} `json:"data"`
+	}
+
+	if err == nil {
+		_, err = c.Do(ctx, req, &record)
+	}
+
+	// Verifying the IDs of a failed request one by one would send many
+	// requests when failures are the most likely, such as while rate
+	// limited. Deletes being idempotent, the IDs can be deleted again.
+	if err != nil {
+		for _, id := range ids {
+			failed = append(failed, BulkDeleteFailure{ID: id, Error: err.Error(), Err: err})
+		}
+
+		return nil, nil, failed
+	}
+
+	reported := map[int]bool{}
+
+	for _, id := range record.Data.ID {
+		reported[id] = true
+	}
+
+	for _, id := range ids {
+		if reported[id] {
+			deleted = append(deleted, id)
+			continue
+		}
+
+		gone, verifyErr := c.verifyDeleted(ctx, path, id)
+
+		switch {
+		case gone:
+			verified = append(verified, id)
+		case verifyErr != nil:
+			failed = append(failed, BulkDeleteFailure{ID: id, Error: verifyErr.Error(), Err: verifyErr})
+		default:
+			err := errors.New("not deleted")
+			failed = append(failed, BulkDeleteFailure{ID: id, Error: err.Error(), Err: err})
+		}
+	}
+
+	return deleted, verified, failed
+}
+
+// verifyDeleted reports whether the record at path with the given ID no
+// longer exists or is marked deleted.
+func (c *Client) verifyDeleted(ctx context.Context, path string, id int) (bool, error) {
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d", path, id), nil, nil)
+
+	if err != nil {
+		return false, err
+	}
+
+	var record struct {
+		Data map[string]interface{} `json:"data"`
+	}
+
+	_, err = c.Do(ctx, req, &record)
+
+	var errorResponse *ErrorResponse
+
+	if errors.As(err, &errorResponse) {
+		switch errorResponse.Response.StatusCode {
+		case http.StatusNotFound, http.StatusGone:
+			return true, nil
+		}
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return record.Data == nil || softDeleted(record.Data), nil
+}
@@ -0,0 +1,71 @@
+package pipedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestClient_BulkDelete(t *testing.T) {
+	var mu sync.Mutex
+	var chunks []string
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "DELETE /v1/deals":
+			mu.Lock()
+			chunks = append(chunks, r.URL.Query().Get("ids"))
+			mu.Unlock()
+
+			switch r.URL.Query().Get("ids") {
+			case "1,2":
+				w.Write([]byte(`{"success": true, "data": {"id": [1]}}`))
+			case "3,4":
+				w.Write([]byte(`{"success": true, "data": {"id": [3, 4]}}`))
+			case "5,6":
+				w.Write([]byte(`{"success": true, "data": {"id": []}}`))
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"success": false, "error": "Internal error"}`))
+			}
+		case "GET /v1/deals/2":
+			w.Write([]byte(`{"success": true, "data": {"id": 2, "status": "deleted"}}`))
+		case "GET /v1/deals/5":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "error": "Deal not found"}`))
+		case "GET /v1/deals/6":
+			w.Write([]byte(`{"success": true, "data": {"id": 6, "status": "open"}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	result, err := client.BulkDelete(context.Background(), OBJECT_DEAL, []int{1, 2, 3, 4, 5, 6, 7, 8}, &BulkDeleteOptions{ChunkSize: 2})
+
+	var bulkDeleteError *BulkDeleteError
+
+	if !errors.As(err, &bulkDeleteError) || !reflect.DeepEqual(bulkDeleteError.IDs, []int{6, 7, 8}) {
+		t.Errorf("Got error %v, want deals 6, 7 and 8 not deleted", err)
+	}
+
+	// The deals of the chunk failing are not fetched.
+	if len(chunks) != 4 {
+		t.Errorf("Got chunks %v, want 4", chunks)
+	}
+
+	if !reflect.DeepEqual(result.Deleted, []int{1, 3, 4}) || !reflect.DeepEqual(result.Verified, []int{2, 5}) {
+		t.Errorf("Got result %v", result)
+	}
+
+	if len(result.Failed) != 3 || result.Failed[0].Err == nil || result.Failed[2].Err == nil {
+		t.Errorf("Got failures %v", result.Failed)
+	}
+
+	if _, err := client.BulkDelete(context.Background(), OBJECT_PRODUCT, []int{1}, nil); err == nil {
+		t.Error("Got no error deleting products in bulk")
+	}
+}
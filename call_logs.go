@@ -0,0 +1,276 @@
+package pipedrive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CallLogsService handles call logs related
+// methods of the Pipedrive API.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs
+type CallLogsService service
+
+// CallLogOutcome is the outcome of a call.
+type CallLogOutcome string
+
+const (
+	CallLogOutcomeConnected     CallLogOutcome = "connected"
+	CallLogOutcomeNoAnswer      CallLogOutcome = "no_answer"
+	CallLogOutcomeLeftMessage   CallLogOutcome = "left_message"
+	CallLogOutcomeLeftVoicemail CallLogOutcome = "left_voicemail"
+	CallLogOutcomeWrongNumber   CallLogOutcome = "wrong_number"
+	CallLogOutcomeBusy          CallLogOutcome = "busy"
+)
+
+// CallDuration is the length of a call. Pipedrive transfers it as a
+// string holding whole seconds.
+type CallDuration time.Duration
+
+func (d CallDuration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d CallDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(time.Duration(d)/time.Second), 10))
+}
+
+func (d *CallDuration) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+
+	if len(b) == 0 || string(b) == "null" {
+		*d = 0
+		return nil
+	}
+
+	seconds, err := strconv.ParseFloat(string(b), 64)
+
+	if err != nil {
+		return err
+	}
+
+	*d = CallDuration(seconds * float64(time.Second))
+
+	return nil
+}
+
+// CallLog represents a Pipedrive call log.
+type CallLog struct {
+	ID              string         `json:"id"`
+	ActivityID      *int           `json:"activity_id"`
+	PersonID        *int           `json:"person_id"`
+	OrgID           *int           `json:"org_id"`
+	DealID          *int           `json:"deal_id"`
+	LeadID          *string        `json:"lead_id"`
+	UserID          int            `json:"user_id"`
+	CompanyID       int            `json:"company_id"`
+	Subject         string         `json:"subject"`
+	Duration        CallDuration   `json:"duration"`
+	Outcome         CallLogOutcome `json:"outcome"`
+	FromPhoneNumber string         `json:"from_phone_number"`
+	ToPhoneNumber   string         `json:"to_phone_number"`
+	HasRecording    bool           `json:"has_recording"`
+	StartTime       string         `json:"start_time"`
+	EndTime         string         `json:"end_time"`
+	Note            string         `json:"note"`
+}
+
+func (c CallLog) String() string {
+	return Stringify(c)
+}
+
+// CallLogsResponse represents multiple call logs response.
+type CallLogsResponse struct {
+	Success        bool           `json:"success"`
+	Data           []CallLog      `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// CallLogResponse represents single call log response.
+type CallLogResponse struct {
+	Success bool    `json:"success"`
+	Data    CallLog `json:"data"`
+}
+
+// List all call logs assigned to the authorized user.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs#getUserCallLogs
+func (s *CallLogsService) List(ctx context.Context, opt *PaginationOptions) (*CallLogsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/callLogs", opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *CallLogsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// GetByID returns a specific call log.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs#getCallLog
+func (s *CallLogsService) GetByID(ctx context.Context, id string) (*CallLogResponse, *Response, error) {
+	uri := fmt.Sprintf("/callLogs/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *CallLogResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// CallLogCreateOptions specifices the optional parameters to the
+// CallLogsService.Create method. Outcome, ToPhoneNumber, StartTime and
+// EndTime are required.
+type CallLogCreateOptions struct {
+	UserID          uint           `json:"user_id,omitempty"`
+	ActivityID      uint           `json:"activity_id,omitempty"`
+	Subject         string         `json:"subject,omitempty"`
+	Duration        CallDuration   `json:"duration,omitempty"`
+	Outcome         CallLogOutcome `json:"outcome"`
+	FromPhoneNumber string         `json:"from_phone_number,omitempty"`
+	ToPhoneNumber   string         `json:"to_phone_number"`
+	StartTime       Timestamp      `json:"start_time"`
+	EndTime         Timestamp      `json:"end_time"`
+	PersonID        uint           `json:"person_id,omitempty"`
+	OrgID           uint           `json:"org_id,omitempty"`
+	DealID          uint           `json:"deal_id,omitempty"`
+	LeadID          string         `json:"lead_id,omitempty"`
+	Note            string         `json:"note,omitempty"`
+}
+
+func (c CallLogCreateOptions) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"outcome":         c.Outcome,
+		"to_phone_number": c.ToPhoneNumber,
+		"start_time":      c.StartTime.UTC().Format(DateTimeLayout),
+		"end_time":        c.EndTime.UTC().Format(DateTimeLayout),
+	}
+
+	if c.UserID != 0 {
+		fields["user_id"] = c.UserID
+	}
+	if c.ActivityID != 0 {
+		fields["activity_id"] = c.ActivityID
+	}
+	if c.Subject != "" {
+		fields["subject"] = c.Subject
+	}
+	if c.Duration != 0 {
+		fields["duration"] = c.Duration
+	}
+	if c.FromPhoneNumber != "" {
+		fields["from_phone_number"] = c.FromPhoneNumber
+	}
+	if c.PersonID != 0 {
+		fields["person_id"] = c.PersonID
+	}
+	if c.OrgID != 0 {
+		fields["org_id"] = c.OrgID
+	}
+	if c.DealID != 0 {
+		fields["deal_id"] = c.DealID
+	}
+	if c.LeadID != "" {
+		fields["lead_id"] = c.LeadID
+	}
+	if c.Note != "" {
+		fields["note"] = c.Note
+	}
+
+	return json.Marshal(fields)
+}
+
+// Create a call log.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs#addCallLog
+func (s *CallLogsService) Create(ctx context.Context, opt *CallLogCreateOptions) (*CallLogResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, "/callLogs", nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *CallLogResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// Delete a call log. Its recording, if any, is deleted as well.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs#deleteCallLog
+func (s *CallLogsService) Delete(ctx context.Context, id string) (*Response, error) {
+	uri := fmt.Sprintf("/callLogs/%v", id)
+	req, err := s.client.NewRequest(http.MethodDelete, uri, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// CallLogRecordingResponse represents a recording upload response.
+type CallLogRecordingResponse struct {
+	Success bool `json:"success"`
+}
+
+// AttachRecording uploads an audio recording of the call and attaches it to
+// the call log. The recording is streamed from r; size may be zero if unknown.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/CallLogs#addCallLogAudioFile
+func (s *CallLogsService) AttachRecording(ctx context.Context, id string, r io.Reader, fileName string, contentType string, size int64) (*CallLogRecordingResponse, *Response, error) {
+	uri := fmt.Sprintf("/callLogs/%v/recordings", id)
+	req, err := s.client.NewMultipartRequest(http.MethodPost, uri, nil, &MultipartFile{
+		FieldName:   "file",
+		FileName:    fileName,
+		ContentType: contentType,
+		Reader:      r,
+		Size:        size,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stop := closeOnDone(ctx, r)
+	defer stop()
+
+	var record *CallLogRecordingResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
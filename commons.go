@@ -1,5 +1,10 @@
 package pipedrive
 
+import (
+	"fmt"
+	"net/url"
+)
+
 const (
 	VisibleToOwnersAndFollowers = 1
 	VisibleToWholeCompany       = 3
@@ -73,6 +78,7 @@ const (
 	OBJECT_ACTIVITY      EventObject = "activity"
 	OBJECT_ACTIVTIY_TYPE EventObject = "activity_type"
 	OBJECT_DEAL          EventObject = "deal"
+	OBJECT_FILE          EventObject = "file"
 	OBJECT_NOTE          EventObject = "note"
 	OBJECT_ORGANIZATION  EventObject = "organization"
 	OBJECT_PERSON        EventObject = "person"
@@ -83,6 +89,44 @@ const (
 	OBJECT_ALL_          EventObject = "*"
 )
 
+// objectCollections maps objects to the path of their collection endpoint.
+var objectCollections = map[EventObject]string{
+	OBJECT_ACTIVITY:      "/activities",
+	OBJECT_ACTIVTIY_TYPE: "/activityTypes",
+	OBJECT_DEAL:          "/deals",
+	OBJECT_FILE:          "/files",
+	OBJECT_NOTE:          "/notes",
+	OBJECT_ORGANIZATION:  "/organizations",
+	OBJECT_PERSON:        "/persons",
+	OBJECT_PIPELINE:      "/pipelines",
+	OBJECT_PRODUCT:       "/products",
+	OBJECT_STAGE:         "/stages",
+	OBJECT_USER:          "/users",
+}
+
+// collectionPath returns the path of the collection endpoint of object.
+func collectionPath(object EventObject) (string, error) {
+	path, ok := objectCollections[object]
+
+	if !ok {
+		return "", fmt.Errorf("unsupported object %q", object)
+	}
+
+	return path, nil
+}
+
+// listQuery returns the query parameters listing every record of object.
+// Activities are only listed for the user of the token unless user_id is 0.
+func listQuery(object EventObject) url.Values {
+	query := url.Values{}
+
+	if object == OBJECT_ACTIVITY {
+		query.Set("user_id", "0")
+	}
+
+	return query
+}
+
 // Active flags
 type ActiveFlag uint8
 
@@ -123,6 +167,11 @@ type VisibleTo uint8
 const (
 	VisibleToOwnersFollowers VisibleTo = 1
 	VisibleToEntireCompany   VisibleTo = 3
+
+	// Companies using visibility groups read 1 as the owner only and 3 as
+	// the visibility group of the owner, and can also use the values below.
+	VisibleToOwnersGroupSubgroups VisibleTo = 5
+	VisibleToAllGroups            VisibleTo = 7
 )
 
 // Deal probability
@@ -138,6 +187,12 @@ type SearchOptions struct {
 	Term string `url:"term,omitempty"`
 }
 
+// Money represents a monetary amount in a given currency.
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
 type Option struct {
 	ID    interface{} `json:"id"` //  int or string
 	Label string      `json:"label"`
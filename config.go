@@ -0,0 +1,1060 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AccountConfig describes the structure of an account: its pipelines and stages,
+// custom fields, activity types and filters. It is encoded as YAML or
+// JSON, to be kept under version control and applied to other accounts.
+//
+// The IDs and keys of a config identify the items in the account it was
+// exported from, given by CompanyID, detecting renames when applied to that
+// account. Applied to another account, items are matched by name and the
+// fields of filter conditions are mapped to the fields of the same name.
+type AccountConfig struct {
+	// CompanyID is the ID of the company of the account the config was
+	// exported from, or 0 if unknown.
+	CompanyID int `json:"company_id,omitempty" yaml:"company_id,omitempty"`
+
+	Pipelines          []PipelineConfig     `json:"pipelines,omitempty" yaml:"pipelines,omitempty"`
+	DealFields         []FieldConfig        `json:"deal_fields,omitempty" yaml:"deal_fields,omitempty"`
+	PersonFields       []FieldConfig        `json:"person_fields,omitempty" yaml:"person_fields,omitempty"`
+	OrganizationFields []FieldConfig        `json:"organization_fields,omitempty" yaml:"organization_fields,omitempty"`
+	ProductFields      []FieldConfig        `json:"product_fields,omitempty" yaml:"product_fields,omitempty"`
+	ActivityTypes      []ActivityTypeConfig `json:"activity_types,omitempty" yaml:"activity_types,omitempty"`
+	Filters            []FilterConfig       `json:"filters,omitempty" yaml:"filters,omitempty"`
+}
+
+func (c AccountConfig) String() string {
+	return Stringify(c)
+}
+
+// PipelineConfig describes a pipeline and its stages, in order.
+type PipelineConfig struct {
+	ID              int           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name            string        `json:"name" yaml:"name"`
+	DealProbability bool          `json:"deal_probability,omitempty" yaml:"deal_probability,omitempty"`
+	Stages          []StageConfig `json:"stages,omitempty" yaml:"stages,omitempty"`
+}
+
+// StageConfig describes a stage of a pipeline.
+type StageConfig struct {
+	ID              int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name            string `json:"name" yaml:"name"`
+	DealProbability int    `json:"deal_probability,omitempty" yaml:"deal_probability,omitempty"`
+
+	// RottenDays is the number of days after which deals rot, or 0 if
+	// they do not.
+	RottenDays int `json:"rotten_days,omitempty" yaml:"rotten_days,omitempty"`
+}
+
+// FieldConfig describes a custom field.
+type FieldConfig struct {
+	ID        int            `json:"id,omitempty" yaml:"id,omitempty"`
+	Key       string         `json:"key,omitempty" yaml:"key,omitempty"`
+	Name      string         `json:"name" yaml:"name"`
+	FieldType FieldType      `json:"field_type" yaml:"field_type"`
+	Options   []OptionConfig `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// OptionConfig describes an option of an enum or set field.
+type OptionConfig struct {
+	ID    int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Label string `json:"label" yaml:"label"`
+}
+
+// ActivityTypeConfig describes an activity type.
+type ActivityTypeConfig struct {
+	ID      int    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name    string `json:"name" yaml:"name"`
+	IconKey string `json:"icon_key" yaml:"icon_key"`
+	Color   string `json:"color,omitempty" yaml:"color,omitempty"`
+
+	// custom is set for the activity types added to the account, which
+	// can be deleted.
+	custom bool
+}
+
+// FilterConfig describes a filter. Its conditions refer to fields, stages
+// and users by ID, which are specific to an account.
+type FilterConfig struct {
+	ID         int         `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       string      `json:"name" yaml:"name"`
+	Type       string      `json:"type" yaml:"type"`
+	Conditions interface{} `json:"conditions" yaml:"conditions"`
+}
+
+// LoadConfig reads a config encoded as YAML or JSON.
+func LoadConfig(r io.Reader) (*AccountConfig, error) {
+	var config AccountConfig
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("config: %v", err)
+	}
+
+	// Conditions are normalized to their JSON representation, YAML maps
+	// and numbers decoding to other types.
+	for i, filter := range config.Filters {
+		b, err := json.Marshal(filter.Conditions)
+
+		if err != nil {
+			return nil, fmt.Errorf("config: filter %q: %v", filter.Name, err)
+		}
+
+		config.Filters[i].Conditions = nil
+
+		if err := json.Unmarshal(b, &config.Filters[i].Conditions); err != nil {
+			return nil, fmt.Errorf("config: filter %q: %v", filter.Name, err)
+		}
+	}
+
+	return &config, nil
+}
+
+// Kinds of config items.
+type ConfigKind string
+
+const (
+	CONFIG_PIPELINE           ConfigKind = "pipeline"
+	CONFIG_STAGE              ConfigKind = "stage"
+	CONFIG_DEAL_FIELD         ConfigKind = "deal_field"
+	CONFIG_PERSON_FIELD       ConfigKind = "person_field"
+	CONFIG_ORGANIZATION_FIELD ConfigKind = "organization_field"
+	CONFIG_PRODUCT_FIELD      ConfigKind = "product_field"
+	CONFIG_ACTIVITY_TYPE      ConfigKind = "activity_type"
+	CONFIG_FILTER             ConfigKind = "filter"
+)
+
+// configFieldKinds maps the kinds of fields to their object.
+var configFieldKinds = map[ConfigKind]EventObject{
+	CONFIG_DEAL_FIELD:         OBJECT_DEAL,
+	CONFIG_PERSON_FIELD:       OBJECT_PERSON,
+	CONFIG_ORGANIZATION_FIELD: OBJECT_ORGANIZATION,
+	CONFIG_PRODUCT_FIELD:      OBJECT_PRODUCT,
+}
+
+// Types of config changes.
+type ConfigChangeType string
+
+const (
+	CONFIG_CREATE ConfigChangeType = "create"
+	CONFIG_UPDATE ConfigChangeType = "update"
+	CONFIG_DELETE ConfigChangeType = "delete"
+)
+
+// ConfigChange creates, updates or deletes an item of an account.
+type ConfigChange struct {
+	Type ConfigChangeType `json:"type"`
+	Kind ConfigKind       `json:"kind"`
+	Name string           `json:"name"`
+
+	// ID of the item updated or deleted, or created once applied.
+	ID int `json:"id,omitempty"`
+
+	// Pipeline is the name of the pipeline of stages.
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// RenamedFrom is the name of an item renamed by an update.
+	RenamedFrom string `json:"renamed_from,omitempty"`
+
+	// Changed lists the attributes updated, such as "options".
+	Changed []string `json:"changed,omitempty"`
+
+	// Path and Body are the request applying the change, kept with plans
+	// saved for review. The body of deletes is empty.
+	Path string                 `json:"path"`
+	Body map[string]interface{} `json:"body,omitempty"`
+}
+
+func (c ConfigChange) String() string {
+	return Stringify(c)
+}
+
+// ConfigPlan lists the changes applying a config to an account, in the
+// order they are applied: creates, then updates, then deletes, filters
+// being deleted first and pipelines last.
+type ConfigPlan struct {
+	Changes []ConfigChange `json:"changes"`
+
+	// Conflicts lists the differences that cannot be applied, such as a
+	// change of field type. A plan with conflicts is not applied.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// Empty reports whether the plan changes nothing.
+func (p ConfigPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p ConfigPlan) String() string {
+	var b strings.Builder
+
+	for _, change := range p.Changes {
+		name := change.Name
+
+		if change.Pipeline != "" {
+			name = change.Pipeline + " / " + name
+		}
+
+		fmt.Fprintf(&b, "%s %s %q", change.Type, change.Kind, name)
+
+		if change.RenamedFrom != "" {
+			fmt.Fprintf(&b, " (renamed from %q)", change.RenamedFrom)
+		}
+
+		if len(change.Changed) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(change.Changed, ", "))
+		}
+
+		b.WriteString("\n")
+	}
+
+	for _, conflict := range p.Conflicts {
+		fmt.Fprintf(&b, "conflict: %s\n", conflict)
+	}
+
+	return b.String()
+}
+
+// ConfigPlanOptions specifices the optional parameters to the
+// Client.PlanConfig method.
+type ConfigPlanOptions struct {
+	// Prune deletes the items of the account missing from the config.
+	// Built-in activity types and fields are never deleted.
+	Prune bool
+}
+
+// ExportConfig returns the config of the account: all pipelines and
+// stages, custom fields, activity types and filters.
+func (c *Client) ExportConfig(ctx context.Context) (*AccountConfig, error) {
+	me, _, err := c.Users.GetCurrentUserData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := &AccountConfig{CompanyID: me.Data.CompanyID}
+
+	pipelines, _, err := c.PipelinesService.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stages, _, err := c.Stages.List(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(stages.Data, func(i, j int) bool {
+		return stages.Data[i].OrderNr < stages.Data[j].OrderNr
+	})
+
+	sort.SliceStable(pipelines.Data, func(i, j int) bool {
+		return pipelines.Data[i].OrderNr < pipelines.Data[j].OrderNr
+	})
+
+	for _, pipeline := range pipelines.Data {
+		p := PipelineConfig{
+			ID:              pipeline.ID,
+			Name:            pipeline.Name,
+			DealProbability: pipeline.DealProbability,
+		}
+
+		for _, stage := range stages.Data {
+			if stage.PipelineID != pipeline.ID {
+				continue
+			}
+
+			s := StageConfig{
+				ID:              stage.ID,
+				Name:            stage.Name,
+				DealProbability: stage.DealProbability,
+			}
+
+			if stage.RottenFlag {
+				s.RottenDays = refID(stage.RottenDays)
+			}
+
+			p.Stages = append(p.Stages, s)
+		}
+
+		config.Pipelines = append(config.Pipelines, p)
+	}
+
+	for _, kind := range []ConfigKind{CONFIG_DEAL_FIELD, CONFIG_PERSON_FIELD, CONFIG_ORGANIZATION_FIELD, CONFIG_PRODUCT_FIELD} {
+		metadata, err := c.FieldMetadata(ctx, configFieldKinds[kind])
+
+		if err != nil {
+			return nil, err
+		}
+
+		var fields []FieldConfig
+
+		for _, field := range metadata[configFieldKinds[kind]] {
+			if !customFieldKey.MatchString(field.Key) {
+				continue
+			}
+
+			f := FieldConfig{
+				ID:        field.ID,
+				Key:       field.Key,
+				Name:      field.Name,
+				FieldType: field.FieldType,
+			}
+
+			for _, option := range field.Options {
+				f.Options = append(f.Options, OptionConfig{
+					ID:    refID(option.ID),
+					Label: option.Label,
+				})
+			}
+
+			fields = append(fields, f)
+		}
+
+		*config.fields(kind) = fields
+	}
+
+	activityTypes, _, err := c.ActivityTypes.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, activityType := range activityTypes.Data {
+		if !activityType.ActiveFlag {
+			continue
+		}
+
+		color, _ := activityType.Color.(string)
+
+		config.ActivityTypes = append(config.ActivityTypes, ActivityTypeConfig{
+			ID:      activityType.ID,
+			Name:    activityType.Name,
+			IconKey: activityType.IconKey,
+			Color:   color,
+			custom:  activityType.IsCustomFlag,
+		})
+	}
+
+	filters, _, err := c.Filters.List(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filter := range filters.Data {
+		record, _, err := c.Filters.GetByID(ctx, filter.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var conditions interface{}
+
+		b, err := json.Marshal(record.Data.Conditions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &conditions); err != nil {
+			return nil, err
+		}
+
+		config.Filters = append(config.Filters, FilterConfig{
+			ID:         filter.ID,
+			Name:       filter.Name,
+			Type:       filter.Type,
+			Conditions: conditions,
+		})
+	}
+
+	return config, nil
+}
+
+// fields returns the fields of a kind.
+func (c *AccountConfig) fields(kind ConfigKind) *[]FieldConfig {
+	switch kind {
+	case CONFIG_DEAL_FIELD:
+		return &c.DealFields
+	case CONFIG_PERSON_FIELD:
+		return &c.PersonFields
+	case CONFIG_ORGANIZATION_FIELD:
+		return &c.OrganizationFields
+	case CONFIG_PRODUCT_FIELD:
+		return &c.ProductFields
+	}
+
+	return nil
+}
+
+// PlanConfig returns the changes applying config to the account.
+func (c *Client) PlanConfig(ctx context.Context, config *AccountConfig, opt *ConfigPlanOptions) (*ConfigPlan, error) {
+	live, err := c.ExportConfig(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffConfig(live, config, opt), nil
+}
+
+// configItem identifies an item matched between configs.
+type configItem struct {
+	id   int
+	key  string
+	name string
+}
+
+// matchConfigItems pairs the desired items with the live ones, first by
+// name, then, if byID is set, by key, then by ID. It returns the index of
+// the live item matching each desired item, or -1, and whether each live
+// item matched.
+func matchConfigItems(live, desired []configItem, byID bool) ([]int, []bool) {
+	matches := make([]int, len(desired))
+	matched := make([]bool, len(live))
+
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	rules := []func(l, d configItem) bool{
+		func(l, d configItem) bool { return d.name != "" && l.name == d.name },
+	}
+
+	if byID {
+		rules = append(rules,
+			func(l, d configItem) bool { return d.key != "" && l.key == d.key },
+			func(l, d configItem) bool { return d.id != 0 && l.id == d.id },
+		)
+	}
+
+	for _, rule := range rules {
+		for i, d := range desired {
+			if matches[i] >= 0 {
+				continue
+			}
+
+			for j, l := range live {
+				if !matched[j] && rule(l, d) {
+					matches[i], matched[j] = j, true
+					break
+				}
+			}
+		}
+	}
+
+	return matches, matched
+}
+
+// DiffConfig returns the changes turning the live config of an account
+// into the desired one.
+//
+// Items are matched by name, then by key or ID, an item matched by key or
+// ID being renamed. Stages are matched within their pipeline, and reordered
+// to their order in the config.
+//
+// IDs and keys are only matched if both configs are of the same company.
+// Otherwise the field IDs and option IDs of filter conditions are mapped to
+// those of the live fields matched by name; a filter whose conditions refer
+// to other fields, such as built-in fields, or to fields or options the
+// plan creates, is reported as a conflict.
+func DiffConfig(live, desired *AccountConfig, opt *ConfigPlanOptions) *ConfigPlan {
+	if opt == nil {
+		opt = &ConfigPlanOptions{}
+	}
+
+	d := &configDiff{
+		prune:       opt.Prune,
+		sameAccount: desired.CompanyID != 0 && desired.CompanyID == live.CompanyID,
+		fieldIDs:    map[EventObject]map[int]int{},
+		optionIDs:   map[EventObject]map[int]map[string]string{},
+	}
+
+	d.pipelines(live.Pipelines, desired.Pipelines)
+
+	for _, kind := range []ConfigKind{CONFIG_DEAL_FIELD, CONFIG_PERSON_FIELD, CONFIG_ORGANIZATION_FIELD, CONFIG_PRODUCT_FIELD} {
+		d.fields(kind, *live.fields(kind), *desired.fields(kind))
+	}
+
+	d.activityTypes(live.ActivityTypes, desired.ActivityTypes)
+	d.filters(live.Filters, desired.Filters)
+
+	plan := &ConfigPlan{Conflicts: d.conflicts}
+
+	plan.Changes = append(plan.Changes, d.creates...)
+	plan.Changes = append(plan.Changes, d.updates...)
+
+	// Filters may refer to stages and fields, and stages must be deleted
+	// before their pipeline.
+	order := []ConfigKind{
+		CONFIG_FILTER,
+		CONFIG_ACTIVITY_TYPE,
+		CONFIG_DEAL_FIELD,
+		CONFIG_PERSON_FIELD,
+		CONFIG_ORGANIZATION_FIELD,
+		CONFIG_PRODUCT_FIELD,
+		CONFIG_STAGE,
+		CONFIG_PIPELINE,
+	}
+
+	for _, kind := range order {
+		for _, change := range d.deletes {
+			if change.Kind == kind {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+	}
+
+	return plan
+}
+
+// configDiff collects the changes of a diff.
+type configDiff struct {
+	prune bool
+
+	// sameAccount is set if IDs and keys identify the same items in both
+	// configs.
+	sameAccount bool
+
+	// fieldIDs maps the IDs of the desired fields to those of the live
+	// fields they match, 0 for fields created, and optionIDs the option IDs
+	// of enum and set fields, by object.
+	fieldIDs  map[EventObject]map[int]int
+	optionIDs map[EventObject]map[int]map[string]string
+
+	creates   []ConfigChange
+	updates   []ConfigChange
+	deletes   []ConfigChange
+	conflicts []string
+}
+
+// change adds a create or update to the diff. Updates changing nothing are
+// dropped.
+func (d *configDiff) change(change ConfigChange) {
+	switch change.Type {
+	case CONFIG_CREATE:
+		d.creates = append(d.creates, change)
+	case CONFIG_UPDATE:
+		if len(change.Changed) > 0 {
+			d.updates = append(d.updates, change)
+		}
+	}
+}
+
+// remove adds the delete of an item to the diff, if pruning.
+func (d *configDiff) remove(kind ConfigKind, path string, id int, name, pipeline string) {
+	if !d.prune {
+		return
+	}
+
+	d.deletes = append(d.deletes, ConfigChange{
+		Type:     CONFIG_DELETE,
+		Kind:     kind,
+		Name:     name,
+		ID:       id,
+		Pipeline: pipeline,
+		Path:     fmt.Sprintf("%s/%d", path, id),
+	})
+}
+
+// update returns the update of a live item, or a create if there is none.
+func update(kind ConfigKind, path string, id int, liveName, name string) ConfigChange {
+	change := ConfigChange{
+		Type: CONFIG_CREATE,
+		Kind: kind,
+		Name: name,
+		Path: path,
+		Body: map[string]interface{}{},
+	}
+
+	if id != 0 {
+		change.Type = CONFIG_UPDATE
+		change.ID = id
+		change.Path = fmt.Sprintf("%s/%d", path, id)
+	}
+
+	if id == 0 || liveName != name {
+		change.Body["name"] = name
+
+		if id != 0 {
+			change.RenamedFrom = liveName
+			change.Changed = append(change.Changed, "name")
+		}
+	}
+
+	return change
+}
+
+// set sets an attribute of a change if it differs from its live value.
+func (c *ConfigChange) set(key string, live, desired, value interface{}) {
+	if c.Type == CONFIG_CREATE {
+		c.Body[key] = value
+	} else if !reflect.DeepEqual(live, desired) {
+		c.Body[key] = value
+		c.Changed = append(c.Changed, key)
+	}
+}
+
+func (d *configDiff) pipelines(live, desired []PipelineConfig) {
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, p := range live {
+		liveItems[i] = configItem{id: p.ID, name: p.Name}
+	}
+
+	for i, p := range desired {
+		desiredItems[i] = configItem{id: p.ID, name: p.Name}
+	}
+
+	matches, matched := matchConfigItems(liveItems, desiredItems, d.sameAccount)
+
+	for i, pipeline := range desired {
+		var current PipelineConfig
+		order := 0
+
+		if matches[i] >= 0 {
+			current, order = live[matches[i]], matches[i]+1
+		}
+
+		change := update(CONFIG_PIPELINE, "/pipelines", current.ID, current.Name, pipeline.Name)
+		change.set("deal_probability", current.DealProbability, pipeline.DealProbability, flag(pipeline.DealProbability))
+		change.set("order_nr", order, i+1, i+1)
+		d.change(change)
+
+		d.stages(pipeline.Name, current.ID, current.Stages, pipeline.Stages)
+	}
+
+	for j, pipeline := range live {
+		if !matched[j] {
+			d.remove(CONFIG_PIPELINE, "/pipelines", pipeline.ID, pipeline.Name, "")
+		}
+	}
+}
+
+func (d *configDiff) stages(pipeline string, pipelineID int, live, desired []StageConfig) {
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, s := range live {
+		liveItems[i] = configItem{id: s.ID, name: s.Name}
+	}
+
+	for i, s := range desired {
+		desiredItems[i] = configItem{id: s.ID, name: s.Name}
+	}
+
+	matches, matched := matchConfigItems(liveItems, desiredItems, d.sameAccount)
+
+	for i, stage := range desired {
+		var current StageConfig
+		order := 0
+
+		if matches[i] >= 0 {
+			current, order = live[matches[i]], matches[i]+1
+		}
+
+		change := update(CONFIG_STAGE, "/stages", current.ID, current.Name, stage.Name)
+		change.Pipeline = pipeline
+
+		if change.Type == CONFIG_CREATE {
+			// The ID of a pipeline created is set once applied.
+			change.Body["pipeline_id"] = pipelineID
+		}
+
+		change.set("deal_probability", current.DealProbability, stage.DealProbability, stage.DealProbability)
+		change.set("rotten_flag", current.RottenDays > 0, stage.RottenDays > 0, flag(stage.RottenDays > 0))
+
+		if stage.RottenDays > 0 {
+			change.set("rotten_days", current.RottenDays, stage.RottenDays, stage.RottenDays)
+		}
+
+		change.set("order_nr", order, i+1, i+1)
+		d.change(change)
+	}
+
+	for j, stage := range live {
+		if !matched[j] {
+			d.remove(CONFIG_STAGE, "/stages", stage.ID, stage.Name, pipeline)
+		}
+	}
+}
+
+func (d *configDiff) fields(kind ConfigKind, live, desired []FieldConfig) {
+	path := fieldCollections[configFieldKinds[kind]]
+
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, f := range live {
+		liveItems[i] = configItem{id: f.ID, key: f.Key, name: f.Name}
+	}
+
+	for i, f := range desired {
+		desiredItems[i] = configItem{id: f.ID, key: f.Key, name: f.Name}
+	}
+
+	matches, matched := matchConfigItems(liveItems, desiredItems, d.sameAccount)
+
+	for i, field := range desired {
+		var current FieldConfig
+
+		if matches[i] >= 0 {
+			current = live[matches[i]]
+
+			if current.FieldType != field.FieldType {
+				d.conflicts = append(d.conflicts, fmt.Sprintf("%s %q: field type cannot change from %s to %s", kind, field.Name, current.FieldType, field.FieldType))
+				matched[matches[i]] = true
+				continue
+			}
+		}
+
+		d.mapField(configFieldKinds[kind], current, field)
+
+		change := update(kind, path, current.ID, current.Name, field.Name)
+
+		if change.Type == CONFIG_CREATE {
+			change.Body["field_type"] = field.FieldType
+		}
+
+		if field.FieldType == FieldTypeEnum || field.FieldType == FieldTypeSet {
+			options := fieldOptions(current.Options, field.Options, d.sameAccount)
+
+			var liveLabels, labels []string
+
+			for _, option := range current.Options {
+				liveLabels = append(liveLabels, option.Label)
+			}
+
+			for _, option := range field.Options {
+				labels = append(labels, option.Label)
+			}
+
+			change.set("options", liveLabels, labels, options)
+		}
+
+		d.change(change)
+	}
+
+	for j, field := range live {
+		if !matched[j] {
+			d.remove(kind, path, field.ID, field.Name, "")
+		}
+	}
+}
+
+// mapField records the live field and options matching a desired field,
+// for filter conditions to refer to them.
+func (d *configDiff) mapField(object EventObject, live, desired FieldConfig) {
+	if desired.ID == 0 {
+		return
+	}
+
+	if d.fieldIDs[object] == nil {
+		d.fieldIDs[object] = map[int]int{}
+		d.optionIDs[object] = map[int]map[string]string{}
+	}
+
+	d.fieldIDs[object][desired.ID] = live.ID
+
+	if desired.FieldType != FieldTypeEnum && desired.FieldType != FieldTypeSet {
+		return
+	}
+
+	options := map[string]string{}
+
+	for _, option := range desired.Options {
+		for _, liveOption := range live.Options {
+			if option.ID != 0 && liveOption.Label == option.Label {
+				options[strconv.Itoa(option.ID)] = strconv.Itoa(liveOption.ID)
+				break
+			}
+		}
+	}
+
+	d.optionIDs[object][desired.ID] = options
+}
+
+// fieldOptions returns the options of a field update, keeping the IDs of
+// the live options matching by label, or by ID for renamed options if
+// byID is set.
+func fieldOptions(live, desired []OptionConfig, byID bool) []map[string]interface{} {
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, o := range live {
+		liveItems[i] = configItem{id: o.ID, name: o.Label}
+	}
+
+	for i, o := range desired {
+		desiredItems[i] = configItem{id: o.ID, name: o.Label}
+	}
+
+	matches, _ := matchConfigItems(liveItems, desiredItems, byID)
+	options := make([]map[string]interface{}, len(desired))
+
+	for i, option := range desired {
+		options[i] = map[string]interface{}{"label": option.Label}
+
+		if matches[i] >= 0 {
+			options[i]["id"] = live[matches[i]].ID
+		}
+	}
+
+	return options
+}
+
+func (d *configDiff) activityTypes(live, desired []ActivityTypeConfig) {
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, a := range live {
+		liveItems[i] = configItem{id: a.ID, name: a.Name}
+	}
+
+	for i, a := range desired {
+		desiredItems[i] = configItem{id: a.ID, name: a.Name}
+	}
+
+	matches, matched := matchConfigItems(liveItems, desiredItems, d.sameAccount)
+
+	for i, activityType := range desired {
+		var current ActivityTypeConfig
+
+		if matches[i] >= 0 {
+			current = live[matches[i]]
+		}
+
+		change := update(CONFIG_ACTIVITY_TYPE, "/activityTypes", current.ID, current.Name, activityType.Name)
+		change.set("icon_key", current.IconKey, activityType.IconKey, activityType.IconKey)
+
+		if activityType.Color != "" {
+			change.set("color", current.Color, activityType.Color, activityType.Color)
+		}
+
+		d.change(change)
+	}
+
+	for j, activityType := range live {
+		if !matched[j] && activityType.custom {
+			d.remove(CONFIG_ACTIVITY_TYPE, "/activityTypes", activityType.ID, activityType.Name, "")
+		}
+	}
+}
+
+func (d *configDiff) filters(live, desired []FilterConfig) {
+	liveItems := make([]configItem, len(live))
+	desiredItems := make([]configItem, len(desired))
+
+	for i, f := range live {
+		liveItems[i] = configItem{id: f.ID, name: f.Name}
+	}
+
+	for i, f := range desired {
+		desiredItems[i] = configItem{id: f.ID, name: f.Name}
+	}
+
+	matches, matched := matchConfigItems(liveItems, desiredItems, d.sameAccount)
+
+	for i, filter := range desired {
+		var current FilterConfig
+
+		if matches[i] >= 0 {
+			current = live[matches[i]]
+
+			if current.Type != filter.Type {
+				d.conflicts = append(d.conflicts, fmt.Sprintf("filter %q: type cannot change from %s to %s", filter.Name, current.Type, filter.Type))
+				matched[matches[i]] = true
+				continue
+			}
+		}
+
+		conditions := filter.Conditions
+
+		if !d.sameAccount {
+			var problem string
+
+			if conditions, problem = d.mapConditions(conditions); problem != "" {
+				d.conflicts = append(d.conflicts, fmt.Sprintf("filter %q: %s", filter.Name, problem))
+
+				if matches[i] >= 0 {
+					matched[matches[i]] = true
+				}
+
+				continue
+			}
+		}
+
+		change := update(CONFIG_FILTER, "/filters", current.ID, current.Name, filter.Name)
+
+		if change.Type == CONFIG_CREATE {
+			change.Body["type"] = filter.Type
+		}
+
+		change.set("conditions", current.Conditions, conditions, conditions)
+		d.change(change)
+	}
+
+	for j, filter := range live {
+		if !matched[j] {
+			d.remove(CONFIG_FILTER, "/filters", filter.ID, filter.Name, "")
+		}
+	}
+}
+
+// mapConditions maps the field IDs and option IDs of filter conditions of
+// another account to those of the live fields. It returns what prevents
+// it, if anything.
+func (d *configDiff) mapConditions(raw interface{}) (interface{}, string) {
+	var conditions FilterConditions
+
+	b, err := json.Marshal(raw)
+
+	if err == nil {
+		err = json.Unmarshal(b, &conditions)
+	}
+
+	if err != nil {
+		return nil, fmt.Sprintf("conditions cannot be read: %v", err)
+	}
+
+	for _, group := range conditions.Conditions {
+		for i := range group.Conditions {
+			c := &group.Conditions[i]
+			id, _ := strconv.Atoi(c.FieldID)
+			liveID, ok := d.fieldIDs[c.Object][id]
+
+			switch {
+			case !ok:
+				return nil, fmt.Sprintf("%s field %s of the condition is not a field of the config", c.Object, c.FieldID)
+			case liveID == 0:
+				return nil, fmt.Sprintf("%s field %s of the condition is created by the plan, plan again once applied", c.Object, c.FieldID)
+			}
+
+			c.FieldID = strconv.Itoa(liveID)
+
+			options, isOption := d.optionIDs[c.Object][id]
+
+			if !isOption || c.Value == "" || c.Operator == FilterOperatorIsNull || c.Operator == FilterOperatorIsNotNull {
+				continue
+			}
+
+			if c.Value, ok = options[c.Value]; !ok {
+				return nil, fmt.Sprintf("option of %s field %d of the condition has no live option of the same label", c.Object, liveID)
+			}
+		}
+	}
+
+	var mapped interface{}
+
+	if b, err = json.Marshal(conditions); err == nil {
+		err = json.Unmarshal(b, &mapped)
+	}
+
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	return mapped, ""
+}
+
+// flag returns the API value of a flag.
+func flag(set bool) int {
+	if set {
+		return 1
+	}
+
+	return 0
+}
+
+// ApplyConfig applies the changes of a plan in order, stopping at the
+// first that fails. The changes applied are returned along with the error,
+// creates holding the ID of the item created.
+func (c *Client) ApplyConfig(ctx context.Context, plan *ConfigPlan) ([]ConfigChange, error) {
+	if len(plan.Conflicts) > 0 {
+		return nil, fmt.Errorf("config plan has %d conflicts: %s", len(plan.Conflicts), strings.Join(plan.Conflicts, "; "))
+	}
+
+	var applied []ConfigChange
+
+	// The IDs of the pipelines created, for the stages created in them.
+	pipelines := map[string]int{}
+
+	for _, change := range plan.Changes {
+		if change.Path == "" || (change.Type != CONFIG_DELETE && change.Body == nil) {
+			return applied, fmt.Errorf("%s %s %q: plan lacks the request of the change", change.Type, change.Kind, change.Name)
+		}
+
+		// The pipeline ID is a float64 in plans decoded from JSON.
+		if change.Kind == CONFIG_STAGE && change.Type == CONFIG_CREATE && fmt.Sprint(change.Body["pipeline_id"]) == "0" {
+			id, ok := pipelines[change.Pipeline]
+
+			if !ok {
+				return applied, fmt.Errorf("create stage %q: pipeline %q not found", change.Name, change.Pipeline)
+			}
+
+			body := map[string]interface{}{}
+
+			for key, value := range change.Body {
+				body[key] = value
+			}
+
+			body["pipeline_id"] = id
+			change.Body = body
+		}
+
+		method := map[ConfigChangeType]string{
+			CONFIG_CREATE: http.MethodPost,
+			CONFIG_UPDATE: http.MethodPut,
+			CONFIG_DELETE: http.MethodDelete,
+		}[change.Type]
+
+		var body interface{}
+
+		if change.Type != CONFIG_DELETE {
+			body = change.Body
+		}
+
+		req, err := c.NewRequest(method, change.Path, nil, body)
+
+		if err != nil {
+			return applied, err
+		}
+
+		id, err := c.doBatchRequest(ctx, req)
+
+		if err != nil {
+			return applied, fmt.Errorf("%s %s %q: %w", change.Type, change.Kind, change.Name, err)
+		}
+
+		if change.Type == CONFIG_CREATE {
+			change.ID = id
+
+			if change.Kind == CONFIG_PIPELINE {
+				pipelines[change.Name] = id
+			}
+		}
+
+		applied = append(applied, change)
+	}
+
+	return applied, nil
+}
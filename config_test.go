@@ -0,0 +1,232 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+company_id: 7
+pipelines:
+  - id: 1
+    name: Sales
+    stages:
+      - name: Qualified
+        deal_probability: 10
+      - id: 12
+        name: Proposal sent
+        rotten_days: 7
+  - name: Renewals
+    stages:
+      - name: Upcoming
+deal_fields:
+  - key: 0123456789abcdef0123456789abcdef01234567
+    name: Segment
+    field_type: enum
+    options:
+      - label: SMB
+      - id: 2
+        label: Mid-market
+      - label: Enterprise
+activity_types:
+  - name: Demo
+    icon_key: camera
+`
+
+func TestDiffConfig(t *testing.T) {
+	desired, err := LoadConfig(strings.NewReader(testConfig))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := &AccountConfig{
+		CompanyID: 7,
+		Pipelines: []PipelineConfig{
+			{ID: 1, Name: "Pipeline", Stages: []StageConfig{
+				{ID: 11, Name: "Qualified", DealProbability: 10},
+				{ID: 12, Name: "Proposal", RottenDays: 7},
+				{ID: 13, Name: "Lost"},
+			}},
+			{ID: 2, Name: "Old"},
+		},
+		DealFields: []FieldConfig{
+			{ID: 5, Key: "0123456789abcdef0123456789abcdef01234567", Name: "Tier", FieldType: FieldTypeEnum, Options: []OptionConfig{
+				{ID: 1, Label: "SMB"},
+				{ID: 2, Label: "Mid market"},
+			}},
+		},
+		ActivityTypes: []ActivityTypeConfig{
+			{ID: 1, Name: "Call", IconKey: "call"},
+			{ID: 7, Name: "Webinar", IconKey: "camera", custom: true},
+		},
+	}
+
+	plan := DiffConfig(live, desired, &ConfigPlanOptions{Prune: true})
+
+	var got []string
+
+	for _, change := range plan.Changes {
+		got = append(got, string(change.Type)+" "+string(change.Kind)+" "+change.Name)
+	}
+
+	want := []string{
+		"create pipeline Renewals",
+		"create stage Upcoming",
+		"create activity_type Demo",
+		"update pipeline Sales",
+		"update stage Proposal sent",
+		"update deal_field Segment",
+		"delete activity_type Webinar",
+		"delete stage Lost",
+		"delete pipeline Old",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Got changes\n%v\nwant\n%v", plan, strings.Join(want, "\n"))
+	}
+
+	for _, change := range plan.Changes {
+		if change.Kind != CONFIG_DEAL_FIELD {
+			continue
+		}
+
+		if change.RenamedFrom != "Tier" || change.Path != "/dealFields/5" {
+			t.Errorf("Got field change %v, want a rename of field 5", change)
+		}
+
+		options := change.Body["options"].([]map[string]interface{})
+
+		if len(options) != 3 || options[0]["id"] != 1 || options[1]["id"] != 2 || options[2]["id"] != nil {
+			t.Errorf("Got options %v, want the IDs of the live options kept", options)
+		}
+	}
+
+	live.DealFields[0].FieldType = FieldTypeSet
+
+	if plan := DiffConfig(live, desired, nil); len(plan.Conflicts) != 1 {
+		t.Errorf("Got conflicts %v, want the field type change", plan.Conflicts)
+	}
+}
+
+func TestDiffConfig_OtherAccount(t *testing.T) {
+	condition := func(fieldID, value interface{}) map[string]interface{} {
+		return map[string]interface{}{"glue": "and", "conditions": []interface{}{
+			map[string]interface{}{"glue": "and", "conditions": []interface{}{
+				map[string]interface{}{"object": "deal", "field_id": fieldID, "operator": "=", "value": value, "extra_value": nil},
+			}},
+			map[string]interface{}{"glue": "or", "conditions": []interface{}{}},
+		}}
+	}
+
+	// The config of a sandbox, whose IDs collide with those of the live
+	// account.
+	desired := &AccountConfig{
+		CompanyID: 8,
+		Pipelines: []PipelineConfig{{ID: 1, Name: "Sales"}},
+		DealFields: []FieldConfig{
+			{ID: 50, Key: "aaaa", Name: "Tier", FieldType: FieldTypeEnum, Options: []OptionConfig{{ID: 60, Label: "Gold"}}},
+		},
+		Filters: []FilterConfig{
+			{ID: 1, Name: "Gold", Type: "deals", Conditions: condition("50", "60")},
+			{ID: 2, Name: "Won", Type: "deals", Conditions: condition("12451", "won")},
+		},
+	}
+
+	live := &AccountConfig{
+		CompanyID: 7,
+		Pipelines: []PipelineConfig{{ID: 1, Name: "Pipeline"}},
+		DealFields: []FieldConfig{
+			{ID: 5, Key: "bbbb", Name: "Tier", FieldType: FieldTypeEnum, Options: []OptionConfig{{ID: 6, Label: "Gold"}}},
+		},
+	}
+
+	plan := DiffConfig(live, desired, nil)
+
+	var got []string
+
+	for _, change := range plan.Changes {
+		got = append(got, string(change.Type)+" "+string(change.Kind)+" "+change.Name)
+	}
+
+	// The live pipeline 1 is not renamed Sales, and the field Tier is
+	// matched by name despite its key.
+	if want := []string{"create pipeline Sales", "create filter Gold"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got changes\n%v\nwant\n%v", plan, strings.Join(want, "\n"))
+	}
+
+	if want := condition("5", "6"); !reflect.DeepEqual(plan.Changes[1].Body["conditions"], want) {
+		t.Errorf("Got conditions %v, want %v", plan.Changes[1].Body["conditions"], want)
+	}
+
+	// The built-in field of the other filter cannot be mapped.
+	if len(plan.Conflicts) != 1 || !strings.Contains(plan.Conflicts[0], `"Won"`) {
+		t.Errorf("Got conflicts %v", plan.Conflicts)
+	}
+}
+
+func TestClient_ApplyConfig(t *testing.T) {
+	var requests []string
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/pipelines":
+			w.Write([]byte(`{"success": true, "data": {"id": 3}}`))
+		case "POST /v1/stages":
+			var stage map[string]interface{}
+
+			if err := json.Unmarshal(body, &stage); err != nil || stage["pipeline_id"] != 3.0 {
+				t.Errorf("Got stage %s, want it in pipeline 3", body)
+			}
+
+			w.Write([]byte(`{"success": true, "data": {"id": 30}}`))
+		case "DELETE /v1/pipelines/2":
+			w.Write([]byte(`{"success": true, "data": {"id": 2}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	live := &AccountConfig{Pipelines: []PipelineConfig{{ID: 2, Name: "Old"}}}
+	desired := &AccountConfig{Pipelines: []PipelineConfig{{Name: "New", Stages: []StageConfig{{Name: "Lead"}}}}}
+
+	// The plan is saved for review and applied once read back.
+	b, err := json.Marshal(DiffConfig(live, desired, &ConfigPlanOptions{Prune: true}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plan ConfigPlan
+
+	if err := json.Unmarshal(b, &plan); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := client.ApplyConfig(context.Background(), &plan)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != 3 || applied[0].ID != 3 || applied[1].ID != 30 {
+		t.Errorf("Got changes applied %v", applied)
+	}
+
+	if len(requests) != 3 || !strings.HasPrefix(requests[2], "DELETE /v1/pipelines/2") {
+		t.Errorf("Got requests %v", requests)
+	}
+
+	if _, err := client.ApplyConfig(context.Background(), &ConfigPlan{Changes: []ConfigChange{{Type: CONFIG_DELETE, Kind: CONFIG_PIPELINE, Name: "Old", ID: 2}}}); err == nil {
+		t.Error("Got no error applying a change without request")
+	}
+}
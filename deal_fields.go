@@ -12,53 +12,14 @@ import (
 // Pipedrive API dcos: https://developers.pipedrive.com/docs/api/v1/#!/DealFields
 type DealFieldsService service
 
-// DealField represents a Pipedrive deal.
-type DealField struct {
-	ID                        int         `json:"id"`
-	Key                       string      `json:"key"`
-	Name                      string      `json:"name"`
-	OrderNr                   int         `json:"order_nr,omitempty"`
-	PicklistData              interface{} `json:"picklist_data,omitempty"`
-	FieldType                 FieldType   `json:"field_type"`
-	AddTime                   string      `json:"add_time,omitempty"`
-	UpdateTime                string      `json:"update_time,omitempty"`
-	ActiveFlag                bool        `json:"active_flag"`
-	EditFlag                  bool        `json:"edit_flag"`
-	IndexVisibleFlag          bool        `json:"index_visible_flag,omitempty"`
-	DetailsVisibleFlag        bool        `json:"details_visible_flag,omitempty"`
-	AddVisibleFlag            bool        `json:"add_visible_flag,omitempty"`
-	ImportantFlag             bool        `json:"important_flag,omitempty"`
-	BulkEditAllowed           bool        `json:"bulk_edit_allowed,omitempty"`
-	SearchableFlag            bool        `json:"searchable_flag,omitempty"`
-	FilteringAllowed          bool        `json:"filtering_allowed,omitempty"`
-	SortableFlag              bool        `json:"sortable_flag,omitempty"`
-	UseField                  string      `json:"use_field,omitempty"`
-	Link                      string      `json:"link,omitempty"`
-	MandatoryFlag             interface{} `json:"mandatory_flag"`
-	IsSubfield                bool        `json:"is_subfield,omitempty"`
-	Options                   []Option    `json:"options,omitempty"`
-	BulkEditAllowedConditions struct {
-		Status string `json:"status"`
-	} `json:"bulk_edit_allowed_conditions,omitempty"`
-}
-
-func (d DealField) String() string {
-	return Stringify(d)
-}
+// DealField represents a Pipedrive deal field.
+type DealField = EntityField
 
 // DealFieldsResponse represents multiple deal fields response.
-type DealFieldsResponse struct {
-	Success        bool           `json:"success"`
-	Data           []DealField    `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type DealFieldsResponse = EntityFieldsResponse
 
 // DealFieldResponse represents single deal field response.
-type DealFieldResponse struct {
-	Success        bool           `json:"success"`
-	Data           DealField      `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type DealFieldResponse = EntityFieldResponse
 
 // List all deal fields.
 //
@@ -106,16 +67,28 @@ func (s *DealFieldsService) GetByID(ctx context.Context, id int) (*DealFieldResp
 // DealFieldCreateOptions specifices the optional parameters to the
 // DealFieldsService.Create method.
 type DealFieldCreateOptions struct {
-	Name      string    `url:"name,omitempty"`
-	FieldType FieldType `url:"field_type,omitempty"`
-	Options   string    `url:"options,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	FieldType FieldType `json:"field_type,omitempty"`
+
+	// Options are the labels of the options of enum and set fields.
+	Options []string `json:"-"`
 }
 
 // Create a new deal field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/DealFields/post_dealFields
 func (s *DealFieldsService) Create(ctx context.Context, opt *DealFieldCreateOptions) (*DealFieldResponse, *Response, error) {
-	req, err := s.client.NewRequest(http.MethodPost, "/dealFields", nil, opt)
+	if opt == nil {
+		opt = &DealFieldCreateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "/dealFields", nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -135,22 +108,36 @@ func (s *DealFieldsService) Create(ctx context.Context, opt *DealFieldCreateOpti
 // DealFieldUpdateOptions specifices the optional parameters to the
 // DealFieldsService.Update method.
 type DealFieldUpdateOptions struct {
-	Name    string `url:"name,omitempty"`
-	Options string `url:"options,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Options replace the options of enum and set fields. Existing options
+	// must be given with their ID, options left out being deleted; new
+	// options only need a label.
+	Options []Option `json:"-"`
 }
 
 // Update a deal field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/DealFields/put_dealFields_id
-func (s *DealFieldsService) Update(ctx context.Context, id int, opt *DealFieldUpdateOptions) (*ProductFieldResponse, *Response, error) {
+func (s *DealFieldsService) Update(ctx context.Context, id int, opt *DealFieldUpdateOptions) (*DealFieldResponse, *Response, error) {
+	if opt == nil {
+		opt = &DealFieldUpdateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	uri := fmt.Sprintf("/dealFields/%v", id)
-	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, body)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var record *ProductFieldResponse
+	var record *DealFieldResponse
 
 	resp, err := s.client.Do(ctx, req, &record)
 
@@ -189,3 +176,55 @@ func (s *DealFieldsService) Delete(ctx context.Context, id uint) (*Response, err
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// AddOption adds an option labeled label to an enum or set deal field,
+// keeping the IDs of the other options.
+func (s *DealFieldsService) AddOption(ctx context.Context, id int, label string) (*FieldDefinition, error) {
+	return s.client.addFieldOption(ctx, OBJECT_DEAL, id, label)
+}
+
+// RenameOption relabels an option of an enum or set deal field, keeping
+// its ID and the values of the records using it.
+func (s *DealFieldsService) RenameOption(ctx context.Context, id int, optionID int, label string) (*FieldDefinition, error) {
+	return s.client.renameFieldOption(ctx, OBJECT_DEAL, id, optionID, label)
+}
+
+// ReorderOptions orders the options of an enum or set deal field as
+// optionIDs, which must list every option.
+func (s *DealFieldsService) ReorderOptions(ctx context.Context, id int, optionIDs []int) (*FieldDefinition, error) {
+	return s.client.reorderFieldOptions(ctx, OBJECT_DEAL, id, optionIDs)
+}
+
+// RemoveOption removes an option of an enum or set deal field. If
+// migrateTo is not 0, the deals using the option are first moved to the
+// option with that ID, and the option is kept if any cannot be moved. The
+// last option of a field cannot be removed.
+func (s *DealFieldsService) RemoveOption(ctx context.Context, id int, optionID int, migrateTo int) (*FieldOptionRemoval, error) {
+	return s.client.removeFieldOption(ctx, OBJECT_DEAL, id, optionID, migrateTo)
+}
+
+// ListFields returns all deal fields. It implements FieldsAPI.
+func (s *DealFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_DEAL)
+}
+
+// GetField returns the deal field with the given ID. It implements
+// FieldsAPI.
+func (s *DealFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_DEAL, id)
+}
+
+// CreateField creates a deal field. It implements FieldsAPI.
+func (s *DealFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_DEAL, opt)
+}
+
+// UpdateField updates a deal field. It implements FieldsAPI.
+func (s *DealFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_DEAL, id, opt)
+}
+
+// DeleteField deletes a deal field. It implements FieldsAPI.
+func (s *DealFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_DEAL, id)
+}
@@ -177,6 +177,50 @@ func (s *DealService) ListUpdates(ctx context.Context, id int) (*DealsResponse,
 	return record, resp, nil
 }
 
+// GetByID returns a specific deal.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Deals/get_deals_id
+func (s *DealService) GetByID(ctx context.Context, id int) (*DealResponse, *Response, error) {
+	uri := fmt.Sprintf("/deals/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *DealResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// ListMailMessages lists mail messages associated with a deal.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Deals#getDealMailMessages
+func (s *DealService) ListMailMessages(ctx context.Context, id int, opt *PaginationOptions) (*MailMessageItemsResponse, *Response, error) {
+	uri := fmt.Sprintf("/deals/%v/mailMessages", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailMessageItemsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
 // Find deals by name.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Deals/get_deals_find
@@ -302,7 +346,7 @@ func (s *DealService) Duplicate(ctx context.Context, id int) (*DealResponse, *Re
 // DealsMergeOptions specifices the optional parameters to the
 // DealService.Merge method.
 type DealsMergeOptions struct {
-	MergeWithID uint `url:"merge_with_id,omitempty"`
+	MergeWithID uint `json:"merge_with_id,omitempty"`
 }
 
 // Merge two deals.
@@ -328,6 +372,7 @@ type DealsUpdateOptions struct {
 	UserID         uint   `json:"user_id,omitempty"`
 	PersonID       uint   `json:"person_id,omitempty"`
 	OrganizationID uint   `json:"org_id,omitempty"`
+	PipelineID     uint   `json:"pipeline_id,omitempty"`
 	StageID        uint   `json:"stage_id,omitempty"`
 	Status         string `json:"status,omitempty"`
 	LostReason     string `json:"lost_reason,omitempty"`
@@ -360,6 +405,9 @@ func (d DealsUpdateOptions) MarshalJSON() ([]byte, error) {
 	if d.OrganizationID != 0 {
 		fields["org_id"] = d.OrganizationID
 	}
+	if d.PipelineID != 0 {
+		fields["pipeline_id"] = d.PipelineID
+	}
 	if d.StageID != 0 {
 		fields["stage_id"] = d.StageID
 	}
@@ -474,6 +522,7 @@ type DealCreateOptions struct {
 	UserID      uint      `json:"user_id,omitempty"`
 	PersonID    uint      `json:"person_id,omitempty"`
 	OrgID       uint      `json:"org_id,omitempty"`
+	PipelineID  uint      `json:"pipeline_id,omitempty"`
 	StageID     uint      `json:"stage_id,omitempty"`
 	Status      string    `json:"status,omitempty"`
 	Probability uint      `json:"probability,omitempty"`
@@ -508,6 +557,9 @@ func (d DealCreateOptions) MarshalJSON() ([]byte, error) {
 	if d.OrgID != 0 {
 		fields["org_id"] = d.OrgID
 	}
+	if d.PipelineID != 0 {
+		fields["pipeline_id"] = d.PipelineID
+	}
 	if d.StageID != 0 {
 		fields["stage_id"] = d.StageID
 	}
@@ -0,0 +1,909 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Keys matching duplicate records.
+type DuplicateKey string
+
+const (
+	// Persons with the same email, ignoring case.
+	DUPLICATE_EMAIL DuplicateKey = "email"
+	// Persons with the same phone, in E.164 format.
+	DUPLICATE_PHONE DuplicateKey = "phone"
+	// Persons of the same organization with similar names, or
+	// organizations with the same name, ignoring case, punctuation and
+	// legal suffixes such as "Inc".
+	DUPLICATE_NAME DuplicateKey = "name"
+	// Organizations with the same website domain.
+	DUPLICATE_DOMAIN DuplicateKey = "domain"
+	// Deals of the same organization and person with the same title.
+	DUPLICATE_TITLE DuplicateKey = "title"
+)
+
+// duplicateKeyWeights are the likelihood that records matched by a key are
+// duplicates.
+var duplicateKeyWeights = map[DuplicateKey]float64{
+	DUPLICATE_EMAIL:  0.95,
+	DUPLICATE_PHONE:  0.85,
+	DUPLICATE_NAME:   0.6,
+	DUPLICATE_DOMAIN: 0.9,
+	DUPLICATE_TITLE:  0.7,
+}
+
+// duplicateKeys are the keys matching duplicates of objects by default.
+var duplicateKeys = map[EventObject][]DuplicateKey{
+	OBJECT_PERSON:       {DUPLICATE_EMAIL, DUPLICATE_PHONE, DUPLICATE_NAME},
+	OBJECT_ORGANIZATION: {DUPLICATE_DOMAIN, DUPLICATE_NAME},
+	OBJECT_DEAL:         {DUPLICATE_TITLE},
+}
+
+// Rules choosing the record surviving the merge of duplicates.
+type SurvivorRule string
+
+const (
+	// The record with the most fields set.
+	SURVIVOR_MOST_COMPLETE SurvivorRule = "most_complete"
+	// The record with the most deals, activities, notes, emails and files.
+	SURVIVOR_MOST_RELATED SurvivorRule = "most_related"
+	// The record added first.
+	SURVIVOR_OLDEST SurvivorRule = "oldest"
+	// The record updated last.
+	SURVIVOR_NEWEST SurvivorRule = "newest"
+)
+
+// mergeCounts are the counts of related records moved to the survivor of
+// a merge.
+var mergeCounts = []string{
+	"activities_count",
+	"done_activities_count",
+	"undone_activities_count",
+	"open_deals_count",
+	"closed_deals_count",
+	"won_deals_count",
+	"lost_deals_count",
+	"people_count",
+	"products_count",
+	"participants_count",
+	"notes_count",
+	"files_count",
+	"email_messages_count",
+	"followers_count",
+}
+
+// mergeIgnoredFields are the fields not previewed by a merge, as they are
+// set by Pipedrive.
+var mergeIgnoredFields = map[string]bool{
+	"id":          true,
+	"company_id":  true,
+	"add_time":    true,
+	"update_time": true,
+	"first_char":  true,
+	"cc_email":    true,
+	"active_flag": true,
+	"org_name":    true,
+	"owner_name":  true,
+	"person_name": true,
+}
+
+// legalSuffixes are ignored comparing organization names.
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true,
+	"limited": true, "corp": true, "corporation": true, "co": true,
+	"company": true, "gmbh": true, "ag": true, "sa": true, "sas": true,
+	"srl": true, "bv": true, "nv": true, "plc": true, "pty": true,
+	"oy": true, "ab": true, "as": true,
+}
+
+// DedupeOptions specifices the optional parameters to the
+// Client.FindDuplicates method.
+type DedupeOptions struct {
+	// Keys match the duplicates. They default to email, phone and name for
+	// persons, domain and name for organizations, and title for deals.
+	Keys []DuplicateKey
+
+	// Survivor rules choose the record surviving a merge, each rule
+	// breaking the ties of the previous one, and the lowest ID the last
+	// ties. They default to the most complete, most related and oldest
+	// record.
+	Survivor []SurvivorRule
+
+	// CountryCode is the calling code, such as "1" or "44", of phones not
+	// in international format.
+	CountryCode string
+
+	// NameSimilarity is the similarity, from 0 to 1, above which the names
+	// of persons of the same organization match. It defaults to 0.85.
+	NameSimilarity float64
+
+	// DomainField is the key of the organization field holding websites.
+	// It defaults to "website".
+	DomainField string
+
+	// MinScore is the score below which groups are dropped.
+	MinScore float64
+
+	// FilterID, if set, only scans the records matching the filter.
+	FilterID int
+}
+
+// DuplicateGroup is a group of records likely to be duplicates.
+type DuplicateGroup struct {
+	Object EventObject `json:"object"`
+
+	// IDs of the records, in ascending order.
+	IDs []int `json:"ids"`
+
+	// Keys matching the records.
+	Keys []DuplicateKey `json:"keys"`
+
+	// Score is the likelihood, from 0 to 1, that the records are
+	// duplicates.
+	Score float64 `json:"score"`
+
+	// SurvivorID is the ID of the record proposed to survive the merge.
+	SurvivorID int `json:"survivor_id"`
+
+	// Records holds the values of the records by ID, as listed.
+	Records map[int]map[string]interface{} `json:"-"`
+}
+
+func (g DuplicateGroup) String() string {
+	return Stringify(g)
+}
+
+// MergeFieldPreview is the value a field will have after a merge.
+type MergeFieldPreview struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+
+	// From is the ID of the record the value comes from, or 0 for emails
+	// and phones, which are combined.
+	From int `json:"from,omitempty"`
+
+	// Discarded lists the other values of the merged records.
+	Discarded []interface{} `json:"discarded,omitempty"`
+}
+
+// MergePreview shows what a merge of duplicates keeps.
+type MergePreview struct {
+	Object     EventObject `json:"object"`
+	SurvivorID int         `json:"survivor_id"`
+	MergedIDs  []int       `json:"merged_ids"`
+
+	// Fields holds the values kept, ordered by key. The survivor keeps
+	// its values, its empty fields taking the value of the first merged
+	// record having one.
+	Fields []MergeFieldPreview `json:"fields"`
+
+	// Counts sums the related records of the merged records, such as
+	// "activities_count", moved to the survivor.
+	Counts map[string]int `json:"counts"`
+
+	// Merged is set when the records were merged.
+	Merged bool `json:"merged"`
+}
+
+func (p MergePreview) String() string {
+	return Stringify(p)
+}
+
+// FindDuplicates scans the persons, organizations or deals and returns the
+// groups of records likely to be duplicates, by descending score. Records
+// are grouped when any key matches, transitively.
+func (c *Client) FindDuplicates(ctx context.Context, object EventObject, opt *DedupeOptions) ([]DuplicateGroup, error) {
+	if opt == nil {
+		opt = &DedupeOptions{}
+	}
+
+	keys := opt.Keys
+
+	if len(keys) == 0 {
+		keys = duplicateKeys[object]
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("finding duplicate %q is not supported", object)
+	}
+
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+
+	if opt.FilterID != 0 {
+		query.Set("filter_id", strconv.Itoa(opt.FilterID))
+	}
+
+	records := map[int]map[string]interface{}{}
+	paginator := c.NewPaginator(path, query)
+
+	for paginator.Next(ctx) {
+		values, err := recordValues(paginator.Raw())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if id := refID(values["id"]); id != 0 && !softDeleted(values) {
+			records[id] = values
+		}
+	}
+
+	if err := paginator.Err(); err != nil {
+		return nil, err
+	}
+
+	return groupDuplicates(object, records, keys, opt), nil
+}
+
+// groupDuplicates groups the records matching by keys.
+func groupDuplicates(object EventObject, records map[int]map[string]interface{}, keys []DuplicateKey, opt *DedupeOptions) []DuplicateGroup {
+	ids := make([]int, 0, len(records))
+
+	for id := range records {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	// The records are joined in sets, each edge joining two records
+	// weighted by the key matching them.
+	parent := make(map[int]int, len(ids))
+
+	for _, id := range ids {
+		parent[id] = id
+	}
+
+	var find func(id int) int
+
+	find = func(id int) int {
+		if p := parent[id]; p != id {
+			parent[id] = find(p)
+			return parent[id]
+		}
+
+		return id
+	}
+
+	weights := map[int]map[DuplicateKey]float64{}
+
+	join := func(a, b int, key DuplicateKey, weight float64) {
+		ra, rb := find(a), find(b)
+
+		if ra != rb {
+			if rb < ra {
+				ra, rb = rb, ra
+			}
+
+			parent[rb] = ra
+
+			for k, w := range weights[rb] {
+				if w > weights[ra][k] {
+					if weights[ra] == nil {
+						weights[ra] = map[DuplicateKey]float64{}
+					}
+
+					weights[ra][k] = w
+				}
+			}
+
+			delete(weights, rb)
+		}
+
+		if weights[ra] == nil {
+			weights[ra] = map[DuplicateKey]float64{}
+		}
+
+		if weight > weights[ra][key] {
+			weights[ra][key] = weight
+		}
+	}
+
+	threshold := opt.NameSimilarity
+
+	if threshold <= 0 {
+		threshold = 0.85
+	}
+
+	for _, key := range keys {
+		weight := duplicateKeyWeights[key]
+
+		if object == OBJECT_PERSON && key == DUPLICATE_NAME {
+			// Persons of the same organization are compared by name
+			// similarity.
+			byOrg := map[int][]int{}
+
+			for _, id := range ids {
+				if org := refID(records[id]["org_id"]); org != 0 {
+					byOrg[org] = append(byOrg[org], id)
+				}
+			}
+
+			for _, members := range byOrg {
+				for i, a := range members {
+					for _, b := range members[i+1:] {
+						name := normalizeName(records[a]["name"], false)
+						other := normalizeName(records[b]["name"], false)
+
+						if name == "" || other == "" {
+							continue
+						}
+
+						if similarity := nameSimilarity(name, other); similarity >= threshold {
+							join(a, b, key, weight*similarity)
+						}
+					}
+				}
+			}
+
+			continue
+		}
+
+		first := map[string]int{}
+
+		for _, id := range ids {
+			for _, value := range duplicateValues(object, key, records[id], opt) {
+				if other, ok := first[value]; ok {
+					join(other, id, key, weight)
+				} else {
+					first[value] = id
+				}
+			}
+		}
+	}
+
+	members := map[int][]int{}
+
+	for _, id := range ids {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+
+	var groups []DuplicateGroup
+
+	for root, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+
+		score := 1.0
+		var matched []DuplicateKey
+
+		for key, weight := range weights[root] {
+			score *= 1 - weight
+			matched = append(matched, key)
+		}
+
+		score = 1 - score
+
+		if score < opt.MinScore {
+			continue
+		}
+
+		sort.Slice(matched, func(i, j int) bool { return matched[i] < matched[j] })
+
+		values := make(map[int]map[string]interface{}, len(group))
+
+		for _, id := range group {
+			values[id] = records[id]
+		}
+
+		groups = append(groups, DuplicateGroup{
+			Object:     object,
+			IDs:        group,
+			Keys:       matched,
+			Score:      score,
+			SurvivorID: chooseSurvivor(group, values, opt.Survivor),
+			Records:    values,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Score != groups[j].Score {
+			return groups[i].Score > groups[j].Score
+		}
+
+		return groups[i].IDs[0] < groups[j].IDs[0]
+	})
+
+	return groups
+}
+
+// duplicateValues returns the normalized values of a record matched by key.
+func duplicateValues(object EventObject, key DuplicateKey, values map[string]interface{}, opt *DedupeOptions) []string {
+	var normalized []string
+
+	switch key {
+	case DUPLICATE_EMAIL:
+		for _, email := range listValues(values["email"]) {
+			if email = strings.ToLower(strings.TrimSpace(email)); strings.Contains(email, "@") {
+				normalized = append(normalized, email)
+			}
+		}
+
+	case DUPLICATE_PHONE:
+		for _, phone := range listValues(values["phone"]) {
+			if phone = normalizePhone(phone, opt.CountryCode); phone != "" {
+				normalized = append(normalized, phone)
+			}
+		}
+
+	case DUPLICATE_NAME:
+		if name := normalizeName(values["name"], object == OBJECT_ORGANIZATION); name != "" {
+			normalized = append(normalized, name)
+		}
+
+	case DUPLICATE_DOMAIN:
+		field := opt.DomainField
+
+		if field == "" {
+			field = "website"
+		}
+
+		for _, website := range listValues(values[field]) {
+			if domain := normalizeDomain(website); domain != "" {
+				normalized = append(normalized, domain)
+			}
+		}
+
+	case DUPLICATE_TITLE:
+		if title := normalizeName(values["title"], false); title != "" {
+			normalized = append(normalized, fmt.Sprintf("%s|%d|%d", title, refID(values["org_id"]), refID(values["person_id"])))
+		}
+	}
+
+	return normalized
+}
+
+// listValues returns the text values of a field, such as the values of a
+// list of emails.
+func listValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case map[string]interface{}:
+		return listValues(v["value"])
+	case []interface{}:
+		var values []string
+
+		for _, item := range v {
+			values = append(values, listValues(item)...)
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+// normalizePhone returns a phone in E.164 format, prefixing phones not in
+// international format with countryCode, or the digits of the phone if
+// countryCode is empty. Phones too short to be numbers return "".
+func normalizePhone(phone, countryCode string) string {
+	phone = strings.TrimSpace(phone)
+
+	// Extensions are dropped.
+	if i := strings.IndexAny(strings.ToLower(phone), "x#"); i > 0 {
+		phone = phone[:i]
+	}
+
+	international := strings.HasPrefix(phone, "+")
+
+	var digits strings.Builder
+
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	number := digits.String()
+
+	if len(number) < 7 {
+		return ""
+	}
+
+	switch {
+	case international:
+		return "+" + number
+	case strings.HasPrefix(number, "00"):
+		return "+" + number[2:]
+	case countryCode != "":
+		return "+" + strings.TrimPrefix(countryCode, "+") + strings.TrimLeft(number, "0")
+	}
+
+	return number
+}
+
+// normalizeName returns the lower case words of a name, sorted, without
+// punctuation, and without legal suffixes if organization is set.
+func normalizeName(value interface{}, organization bool) string {
+	name, _ := value.(string)
+
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := words[:0]
+
+	for _, word := range words {
+		if !organization || !legalSuffixes[word] {
+			kept = append(kept, word)
+		}
+	}
+
+	sort.Strings(kept)
+
+	return strings.Join(kept, " ")
+}
+
+// normalizeDomain returns the domain of a website, without "www.".
+func normalizeDomain(website string) string {
+	website = strings.ToLower(strings.TrimSpace(website))
+
+	if website == "" {
+		return ""
+	}
+
+	if !strings.Contains(website, "://") {
+		website = "http://" + website
+	}
+
+	u, err := url.Parse(website)
+
+	if err != nil {
+		return ""
+	}
+
+	domain := strings.TrimPrefix(u.Hostname(), "www.")
+
+	if !strings.Contains(domain, ".") {
+		return ""
+	}
+
+	return domain
+}
+
+// nameSimilarity returns the similarity of two names, from 0 to 1, as one
+// minus their edit distance relative to the longest.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	longest := len(ra)
+
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
+
+// chooseSurvivor returns the ID of the record surviving the merge of a
+// group according to rules.
+func chooseSurvivor(ids []int, records map[int]map[string]interface{}, rules []SurvivorRule) int {
+	if len(rules) == 0 {
+		rules = []SurvivorRule{SURVIVOR_MOST_COMPLETE, SURVIVOR_MOST_RELATED, SURVIVOR_OLDEST}
+	}
+
+	survivor := ids[0]
+
+	for _, id := range ids[1:] {
+		for _, rule := range rules {
+			if c := compareSurvivors(rule, records[id], records[survivor]); c != 0 {
+				if c > 0 {
+					survivor = id
+				}
+
+				break
+			}
+		}
+	}
+
+	return survivor
+}
+
+// compareSurvivors returns a positive number if a is preferred to b by
+// rule, negative if b is, and 0 if neither.
+func compareSurvivors(rule SurvivorRule, a, b map[string]interface{}) int {
+	switch rule {
+	case SURVIVOR_MOST_COMPLETE:
+		return completeness(a) - completeness(b)
+
+	case SURVIVOR_MOST_RELATED:
+		return relatedCount(a) - relatedCount(b)
+
+	case SURVIVOR_OLDEST:
+		at, _ := a["add_time"].(string)
+		bt, _ := b["add_time"].(string)
+
+		return strings.Compare(bt, at)
+
+	case SURVIVOR_NEWEST:
+		at, _ := a["update_time"].(string)
+		bt, _ := b["update_time"].(string)
+
+		return strings.Compare(at, bt)
+	}
+
+	return 0
+}
+
+// completeness counts the fields of a record that are set.
+func completeness(values map[string]interface{}) int {
+	count := 0
+
+	for key, value := range values {
+		if !mergeIgnoredFields[key] && !strings.HasSuffix(key, "_count") && !emptyValue(value) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// relatedCount sums the related records of a record.
+func relatedCount(values map[string]interface{}) int {
+	count := 0
+
+	for _, key := range mergeCounts {
+		if n, ok := values[key].(float64); ok {
+			count += int(n)
+		}
+	}
+
+	return count
+}
+
+// emptyValue reports whether a field value is unset.
+func emptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		for _, item := range v {
+			if !emptyValue(item) {
+				return false
+			}
+		}
+
+		return true
+	case map[string]interface{}:
+		if value, ok := v["value"]; ok {
+			return emptyValue(value)
+		}
+
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// refID returns the ID of a reference to a record, such as the org_id of a
+// person, which is either the ID or an object holding it under "value".
+func refID(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		id, _ := strconv.Atoi(v)
+		return id
+	case map[string]interface{}:
+		if id, ok := v["value"]; ok {
+			return refID(id)
+		}
+
+		return refID(v["id"])
+	}
+
+	return 0
+}
+
+// Preview returns what merging the records of the group into the survivor
+// keeps, from the values listed.
+func (g DuplicateGroup) Preview() *MergePreview {
+	preview := &MergePreview{
+		Object:     g.Object,
+		SurvivorID: g.SurvivorID,
+		Counts:     map[string]int{},
+	}
+
+	// The survivor comes first, so its values are kept.
+	order := []int{g.SurvivorID}
+
+	for _, id := range g.IDs {
+		if id != g.SurvivorID {
+			order = append(order, id)
+			preview.MergedIDs = append(preview.MergedIDs, id)
+		}
+	}
+
+	keys := map[string]bool{}
+
+	for _, id := range order {
+		for key, value := range g.Records[id] {
+			if n, ok := value.(float64); ok && strings.HasSuffix(key, "_count") {
+				for _, count := range mergeCounts {
+					if key == count {
+						preview.Counts[key] += int(n)
+					}
+				}
+
+				continue
+			}
+
+			if !mergeIgnoredFields[key] {
+				keys[key] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		field := MergeFieldPreview{Key: key}
+
+		if key == "email" || key == "phone" {
+			// Emails and phones of the merged records are combined.
+			seen := map[string]bool{}
+			var combined []interface{}
+
+			for _, id := range order {
+				items, _ := g.Records[id][key].([]interface{})
+
+				for _, item := range items {
+					text := listValues(item)
+
+					if len(text) == 0 || strings.TrimSpace(text[0]) == "" {
+						continue
+					}
+
+					normalized := strings.ToLower(strings.TrimSpace(text[0]))
+
+					if key == "phone" {
+						if phone := normalizePhone(text[0], ""); phone != "" {
+							normalized = phone
+						}
+					}
+
+					if !seen[normalized] {
+						seen[normalized] = true
+						combined = append(combined, item)
+					}
+				}
+			}
+
+			if len(combined) > 0 {
+				field.Value = combined
+				preview.Fields = append(preview.Fields, field)
+			}
+
+			continue
+		}
+
+		for _, id := range order {
+			value := g.Records[id][key]
+
+			if emptyValue(value) {
+				continue
+			}
+
+			switch {
+			case field.From == 0:
+				field.Value, field.From = value, id
+			case !sameValue(field.Value, value):
+				field.Discarded = append(field.Discarded, value)
+			}
+		}
+
+		if field.From != 0 {
+			preview.Fields = append(preview.Fields, field)
+		}
+	}
+
+	return preview
+}
+
+// sameValue reports whether two field values are equal, references being
+// compared by ID.
+func sameValue(a, b interface{}) bool {
+	if id := refID(a); id != 0 {
+		if _, ok := a.(map[string]interface{}); ok {
+			return id == refID(b)
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// MergeDuplicates merges the records of a group into its survivor and
+// returns a preview of what the merge keeps. If dryRun is set, the records
+// are not merged.
+func (c *Client) MergeDuplicates(ctx context.Context, group DuplicateGroup, dryRun bool) (*MergePreview, error) {
+	preview := group.Preview()
+
+	if dryRun {
+		return preview, nil
+	}
+
+	for _, id := range preview.MergedIDs {
+		var err error
+
+		switch group.Object {
+		case OBJECT_PERSON:
+			_, _, err = c.Persons.Merge(ctx, id, group.SurvivorID)
+		case OBJECT_ORGANIZATION:
+			_, _, err = c.Organizations.Merge(ctx, id, group.SurvivorID)
+		case OBJECT_DEAL:
+			_, err = c.Deals.Merge(ctx, id, &DealsMergeOptions{MergeWithID: uint(group.SurvivorID)})
+		default:
+			return preview, fmt.Errorf("merging %q is not supported", group.Object)
+		}
+
+		if err != nil {
+			return preview, fmt.Errorf("merge %s %d into %d: %w", group.Object, id, group.SurvivorID, err)
+		}
+	}
+
+	preview.Merged = true
+
+	return preview, nil
+}
@@ -0,0 +1,116 @@
+package pipedrive
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_FindDuplicates(t *testing.T) {
+	var merged []string
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/persons":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 1, "name": "Jane Doe", "email": [{"value": "Jane@Example.com"}], "add_time": "2026-01-01 00:00:00", "activities_count": 2},
+				{"id": 2, "name": "J. Doe", "email": [{"value": "jane@example.com"}], "phone": [{"value": "+1 555 000 1111"}], "job_title": "CEO", "add_time": "2026-02-01 00:00:00", "activities_count": 3},
+				{"id": 3, "name": "Ann Lee", "phone": [{"value": "+1 (555) 123-4567"}]},
+				{"id": 4, "name": "Anne Lee", "phone": [{"value": "555.123.4567"}]},
+				{"id": 5, "name": "Jon Smith", "org_id": {"value": 9, "name": "Acme"}},
+				{"id": 6, "name": "John Smith", "org_id": {"value": 9, "name": "Acme"}},
+				{"id": 7, "name": "John Smith", "org_id": {"value": 8, "name": "Other"}},
+				{"id": 8, "name": "Old", "email": [{"value": "jane@example.com"}], "active_flag": false}
+			], "additional_data": {"pagination": {"more_items_in_collection": false}}}`))
+		case "PUT /v1/persons/1/merge":
+			merged = append(merged, r.URL.Path)
+			w.Write([]byte(`{"success": true, "data": {"id": 2}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	groups, err := client.FindDuplicates(ctx, OBJECT_PERSON, &DedupeOptions{CountryCode: "1"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids [][]int
+
+	for _, group := range groups {
+		ids = append(ids, group.IDs)
+	}
+
+	if want := [][]int{{1, 2}, {3, 4}, {5, 6}}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Got groups %v, want %v", ids, want)
+	}
+
+	if groups[0].SurvivorID != 2 || !reflect.DeepEqual(groups[0].Keys, []DuplicateKey{DUPLICATE_EMAIL}) {
+		t.Errorf("Got group %v, want survivor 2 matched by email", groups[0])
+	}
+
+	preview, err := client.MergeDuplicates(ctx, groups[0], true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if preview.Merged || len(merged) != 0 || preview.Counts["activities_count"] != 5 {
+		t.Errorf("Got preview %v", preview)
+	}
+
+	for _, field := range preview.Fields {
+		switch field.Key {
+		case "name":
+			if field.Value != "J. Doe" || field.From != 2 || len(field.Discarded) != 1 {
+				t.Errorf("Got name %v", field)
+			}
+		case "email":
+			if len(field.Value.([]interface{})) != 1 {
+				t.Errorf("Got emails %v, want one", field.Value)
+			}
+		}
+	}
+
+	if _, err := client.MergeDuplicates(ctx, groups[0], false); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(merged, []string{"/v1/persons/1/merge"}) {
+		t.Errorf("Got merges %v", merged)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, countryCode, want string
+	}{
+		{"+44 20 7946 0958", "", "+442079460958"},
+		{"0044 20 7946 0958", "", "+442079460958"},
+		{"020 7946 0958", "44", "+442079460958"},
+		{"(555) 123-4567 x12", "1", "+15551234567"},
+		{"555-1234", "", "5551234"},
+		{"123", "1", ""},
+	}
+
+	for _, test := range tests {
+		if got := normalizePhone(test.phone, test.countryCode); got != test.want {
+			t.Errorf("normalizePhone(%q, %q) = %q, want %q", test.phone, test.countryCode, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	if a, b := normalizeName("Acme, Inc.", true), normalizeName("ACME", true); a != b {
+		t.Errorf("Got %q and %q, want equal names", a, b)
+	}
+
+	if got := normalizeName("Smith John", false); got != normalizeName("john smith", false) {
+		t.Errorf("Got %q, want words sorted", got)
+	}
+}
@@ -0,0 +1,465 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DeletionState holds what a DeletionDetector knows about the records of
+// an object.
+type DeletionState struct {
+	// IDs lists the records found active by the last detection.
+	IDs []int `json:"ids"`
+
+	// Merges maps the IDs of merged records to the ID of the record they
+	// were merged with, until their tombstone is reported.
+	Merges map[int]int `json:"merges,omitempty"`
+}
+
+func (s DeletionState) String() string {
+	return Stringify(s)
+}
+
+// DeletionStateStore persists deletion states, keyed by object.
+type DeletionStateStore interface {
+	// Load returns the state saved for object, or nil if there is none.
+	Load(ctx context.Context, object EventObject) (*DeletionState, error)
+
+	// Save replaces the state saved for object.
+	Save(ctx context.Context, object EventObject, state *DeletionState) error
+}
+
+// MemoryDeletionStateStore keeps deletion states in memory.
+type MemoryDeletionStateStore struct {
+	mu     sync.Mutex
+	states map[EventObject][]byte
+}
+
+// NewMemoryDeletionStateStore returns an empty in-memory deletion state
+// store.
+func NewMemoryDeletionStateStore() *MemoryDeletionStateStore {
+	return &MemoryDeletionStateStore{states: map[EventObject][]byte{}}
+}
+
+// Load returns the state saved for object, or nil if there is none.
+func (s *MemoryDeletionStateStore) Load(ctx context.Context, object EventObject) (*DeletionState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.states[object]
+
+	if !ok {
+		return nil, nil
+	}
+
+	var state *DeletionState
+
+	return state, json.Unmarshal(b, &state)
+}
+
+// Save replaces the state saved for object.
+func (s *MemoryDeletionStateStore) Save(ctx context.Context, object EventObject, state *DeletionState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(state)
+
+	if err != nil {
+		return err
+	}
+
+	s.states[object] = b
+
+	return nil
+}
+
+// FileDeletionStateStore keeps deletion states in a JSON file. The file is
+// replaced atomically on each save.
+type FileDeletionStateStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileDeletionStateStore returns a deletion state store using the file
+// at path, which is created on the first save.
+func NewFileDeletionStateStore(path string) *FileDeletionStateStore {
+	return &FileDeletionStateStore{Path: path}
+}
+
+// Load returns the state saved for object, or nil if there is none.
+func (s *FileDeletionStateStore) Load(ctx context.Context, object EventObject) (*DeletionState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := map[EventObject]*DeletionState{}
+
+	if err := readJSONFile(s.Path, &states); err != nil {
+		return nil, err
+	}
+
+	return states[object], nil
+}
+
+// Save replaces the state saved for object.
+func (s *FileDeletionStateStore) Save(ctx context.Context, object EventObject, state *DeletionState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := map[EventObject]*DeletionState{}
+
+	if err := readJSONFile(s.Path, &states); err != nil {
+		return err
+	}
+
+	states[object] = state
+
+	return writeJSONFile(s.Path, states)
+}
+
+// Type of tombstones.
+type TombstoneReason string
+
+const (
+	// The record no longer exists.
+	TOMBSTONE_DELETED TombstoneReason = "deleted"
+	// The record still exists, marked deleted or inactive.
+	TOMBSTONE_SOFT_DELETED TombstoneReason = "soft_deleted"
+	// The record was merged with another record.
+	TOMBSTONE_MERGED TombstoneReason = "merged"
+)
+
+// Tombstone reports a record that disappeared since the last detection.
+type Tombstone struct {
+	Object EventObject     `json:"object"`
+	ID     int             `json:"id"`
+	Reason TombstoneReason `json:"reason"`
+
+	// MergedInto is the ID of the record a merged record was merged with,
+	// following subsequent merges.
+	MergedInto int `json:"merged_into,omitempty"`
+
+	// Record is the soft deleted record as returned by the API.
+	Record json.RawMessage `json:"record,omitempty"`
+}
+
+func (t Tombstone) String() string {
+	return Stringify(t)
+}
+
+// deletionObjects are the objects whose deletions can be detected.
+var deletionObjects = map[EventObject]bool{
+	OBJECT_ACTIVITY:     true,
+	OBJECT_DEAL:         true,
+	OBJECT_NOTE:         true,
+	OBJECT_ORGANIZATION: true,
+	OBJECT_PERSON:       true,
+	OBJECT_PRODUCT:      true,
+}
+
+// DeletionDetector finds the records deleted since it last ran, which
+// neither the list endpoints nor Recents report, by comparing the IDs
+// listed with those saved in a state store.
+//
+// Records merged through the Merge method, or reported merged with
+// RecordMerge, such as from merged webhook events, are reported as merged
+// rather than deleted.
+type DeletionDetector struct {
+	client *Client
+	store  DeletionStateStore
+
+	// mu serializes the updates of the store.
+	mu sync.Mutex
+
+	// PageSize is the number of records requested per page, up to 500. It
+	// defaults to 500.
+	PageSize uint
+}
+
+// NewDeletionDetector returns a deletion detector saving its state in
+// store.
+func (c *Client) NewDeletionDetector(store DeletionStateStore) *DeletionDetector {
+	return &DeletionDetector{
+		client: c,
+		store:  store,
+	}
+}
+
+// Detect lists the records of object and returns a tombstone for each
+// record listed by the previous detection and now missing. The first
+// detection of an object only saves the IDs listed.
+//
+// A missing record is fetched to tell soft deletes, such as persons with
+// an active_flag of false or deals with the deleted status, from records
+// that no longer exist. Records found active again, due to changes made
+// while listing, are kept.
+//
+// Deals, persons, organizations, activities, notes and products are
+// supported.
+func (d *DeletionDetector) Detect(ctx context.Context, object EventObject) ([]Tombstone, error) {
+	if !deletionObjects[object] {
+		return nil, fmt.Errorf("deletion detection of %q is not supported", object)
+	}
+
+	previous, err := d.store.Load(ctx, object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Soft deleted records are only kept if listed before, to be reported.
+	tracked := map[int]bool{}
+
+	if previous != nil {
+		for _, id := range previous.IDs {
+			tracked[id] = true
+		}
+	}
+
+	active, inactive, err := d.list(ctx, object, "", tracked)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Listing deals excludes deleted deals, list them apart.
+	if object == OBJECT_DEAL && previous != nil {
+		_, deleted, err := d.list(ctx, object, "deleted", tracked)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for id, data := range deleted {
+			inactive[id] = data
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Merges may have been recorded while listing.
+	current, err := d.store.Load(ctx, object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	merges := map[int]int{}
+
+	if current != nil {
+		merges = current.Merges
+	}
+
+	var tombstones []Tombstone
+
+	if previous != nil {
+		for _, id := range previous.IDs {
+			if _, ok := active[id]; ok {
+				continue
+			}
+
+			tombstone := Tombstone{Object: object, ID: id}
+
+			if into, ok := merges[id]; ok {
+				tombstone.Reason = TOMBSTONE_MERGED
+				tombstone.MergedInto = resolveMerge(merges, into)
+			} else if data, ok := inactive[id]; ok {
+				tombstone.Reason = TOMBSTONE_SOFT_DELETED
+				tombstone.Record = data
+			} else {
+				tombstone.Reason, tombstone.Record, err = d.verify(ctx, object, id)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if tombstone.Reason == "" {
+					active[id] = struct{}{}
+					continue
+				}
+			}
+
+			tombstones = append(tombstones, tombstone)
+		}
+	}
+
+	state := &DeletionState{Merges: map[int]int{}}
+
+	for id := range active {
+		state.IDs = append(state.IDs, id)
+	}
+
+	sort.Ints(state.IDs)
+
+	// Keep the merges of records still listed, the others were reported or
+	// are of records never listed.
+	for id, into := range merges {
+		if _, ok := active[id]; ok {
+			state.Merges[id] = into
+		}
+	}
+
+	if err := d.store.Save(ctx, object, state); err != nil {
+		return nil, err
+	}
+
+	return tombstones, nil
+}
+
+// RecordMerge records that the record of object with the given id was
+// merged with the record with ID mergeWithID, for the next detection to
+// report it merged.
+func (d *DeletionDetector) RecordMerge(ctx context.Context, object EventObject, id int, mergeWithID int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, err := d.store.Load(ctx, object)
+
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		state = &DeletionState{}
+	}
+
+	if state.Merges == nil {
+		state.Merges = map[int]int{}
+	}
+
+	state.Merges[id] = mergeWithID
+
+	return d.store.Save(ctx, object, state)
+}
+
+// Merge merges the deal, person or organization with the given id with the
+// one with ID mergeWithID, and records the merge.
+func (d *DeletionDetector) Merge(ctx context.Context, object EventObject, id int, mergeWithID int) error {
+	var err error
+
+	switch object {
+	case OBJECT_DEAL:
+		_, err = d.client.Deals.Merge(ctx, id, &DealsMergeOptions{MergeWithID: uint(mergeWithID)})
+	case OBJECT_PERSON:
+		_, _, err = d.client.Persons.Merge(ctx, id, mergeWithID)
+	case OBJECT_ORGANIZATION:
+		_, _, err = d.client.Organizations.Merge(ctx, id, mergeWithID)
+	default:
+		return fmt.Errorf("merging %q is not supported", object)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return d.RecordMerge(ctx, object, id, mergeWithID)
+}
+
+// resolveMerge follows the merges of the record with the given id.
+func resolveMerge(merges map[int]int, id int) int {
+	for i := 0; i < len(merges); i++ {
+		into, ok := merges[id]
+
+		if !ok {
+			break
+		}
+
+		id = into
+	}
+
+	return id
+}
+
+// list returns the IDs of the active records of object, and the soft
+// deleted records among those tracked by ID.
+func (d *DeletionDetector) list(ctx context.Context, object EventObject, status string, tracked map[int]bool) (map[int]struct{}, map[int]json.RawMessage, error) {
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opt := listQuery(object)
+
+	if status != "" {
+		opt.Set("status", status)
+	}
+
+	if d.PageSize > 0 {
+		opt.Set("limit", strconv.Itoa(int(d.PageSize)))
+	}
+
+	active := map[int]struct{}{}
+	inactive := map[int]json.RawMessage{}
+	paginator := d.client.NewPaginator(path, opt)
+
+	for paginator.Next(ctx) {
+		var entity struct {
+			ID         int         `json:"id"`
+			Status     interface{} `json:"status"`
+			Deleted    interface{} `json:"deleted"`
+			ActiveFlag interface{} `json:"active_flag"`
+		}
+
+		if err := paginator.Decode(&entity); err != nil {
+			return nil, nil, err
+		}
+
+		values := map[string]interface{}{
+			"status":      entity.Status,
+			"deleted":     entity.Deleted,
+			"active_flag": entity.ActiveFlag,
+		}
+
+		switch {
+		case !softDeleted(values):
+			active[entity.ID] = struct{}{}
+		case tracked[entity.ID]:
+			inactive[entity.ID] = paginator.Raw()
+		}
+	}
+
+	return active, inactive, paginator.Err()
+}
+
+// verify fetches a record missing from the listing and returns why it is
+// missing, or an empty reason if it is active.
+func (d *DeletionDetector) verify(ctx context.Context, object EventObject, id int) (TombstoneReason, json.RawMessage, error) {
+	data, err := d.client.getRecord(ctx, object, id)
+
+	var errorResponse *ErrorResponse
+
+	if errors.As(err, &errorResponse) {
+		switch errorResponse.Response.StatusCode {
+		case http.StatusNotFound, http.StatusGone:
+			return TOMBSTONE_DELETED, nil, nil
+		}
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(data) == 0 || string(data) == "null" {
+		return TOMBSTONE_DELETED, nil, nil
+	}
+
+	values, err := recordValues(data)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if softDeleted(values) {
+		return TOMBSTONE_SOFT_DELETED, data, nil
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,103 @@
+package pipedrive
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDeletionDetector_Detect(t *testing.T) {
+	listing := `{"success": true, "data": [{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}, {"id": 5}]}`
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/persons":
+			w.Write([]byte(listing))
+		case "/v1/persons/2":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "error": "Person not found"}`))
+		case "/v1/persons/5":
+			w.Write([]byte(`{"success": true, "data": {"id": 5, "active_flag": false}}`))
+		default:
+			t.Errorf("Got unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+	detector := client.NewDeletionDetector(NewMemoryDeletionStateStore())
+
+	tombstones, err := detector.Detect(ctx, OBJECT_PERSON)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tombstones) != 0 {
+		t.Errorf("Got tombstones %v on the first detection", tombstones)
+	}
+
+	if err := detector.RecordMerge(ctx, OBJECT_PERSON, 3, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	listing = `{"success": true, "data": [{"id": 1}, {"id": 4, "active_flag": false}]}`
+
+	tombstones, err = detector.Detect(ctx, OBJECT_PERSON)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int]TombstoneReason{
+		2: TOMBSTONE_DELETED,
+		3: TOMBSTONE_MERGED,
+		4: TOMBSTONE_SOFT_DELETED,
+		5: TOMBSTONE_SOFT_DELETED,
+	}
+
+	if len(tombstones) != len(want) {
+		t.Fatalf("Got tombstones %v", tombstones)
+	}
+
+	for _, tombstone := range tombstones {
+		if tombstone.Reason != want[tombstone.ID] {
+			t.Errorf("Got tombstone %v, want reason %s", tombstone, want[tombstone.ID])
+		}
+
+		if tombstone.ID == 3 && tombstone.MergedInto != 1 {
+			t.Errorf("Got person 3 merged into %d, want 1", tombstone.MergedInto)
+		}
+	}
+
+	tombstones, err = detector.Detect(ctx, OBJECT_PERSON)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tombstones) != 0 {
+		t.Errorf("Got tombstones %v reported again", tombstones)
+	}
+}
+
+func TestDeletionDetector_listActivities(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/activities" || r.URL.Query().Get("user_id") != "0" {
+			t.Errorf("Got unexpected request %s, want activities of every user", r.URL)
+		}
+
+		w.Write([]byte(`{"success": true, "data": [{"id": 1, "user_id": 7}, {"id": 2, "active_flag": false}, {"id": 3, "active_flag": false}]}`))
+	}))
+
+	active, inactive, err := client.NewDeletionDetector(NewMemoryDeletionStateStore()).list(context.Background(), OBJECT_ACTIVITY, "", map[int]bool{2: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the soft deleted records tracked are kept.
+	if _, ok := active[1]; !ok || len(active) != 1 || len(inactive) != 1 || inactive[2] == nil {
+		t.Errorf("Got active records %v and inactive records %v", active, inactive)
+	}
+}
@@ -0,0 +1,264 @@
+package pipedrive
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// diffNoiseFields are fields changing as a side effect of other changes.
+// Fields ending in "_count" are noise as well.
+var diffNoiseFields = map[string]bool{
+	"update_time":              true,
+	"stage_change_time":        true,
+	"next_activity_date":       true,
+	"next_activity_time":       true,
+	"next_activity_id":         true,
+	"next_activity_subject":    true,
+	"next_activity_type":       true,
+	"next_activity_duration":   true,
+	"next_activity_note":       true,
+	"last_activity_id":         true,
+	"last_activity_date":       true,
+	"last_incoming_mail_time":  true,
+	"last_outgoing_mail_time":  true,
+	"formatted_value":          true,
+	"weighted_value":           true,
+	"formatted_weighted_value": true,
+	"rotten_time":              true,
+}
+
+func isDiffNoise(key string) bool {
+	return diffNoiseFields[key] || strings.HasSuffix(key, "_count")
+}
+
+// FieldChange describes how a field differs between two versions of a
+// record. Name, and the types of Old and New, are resolved from field
+// metadata when available: numbers are float64, IDs of users, people,
+// organizations and stages are int, dates are Date or Timestamp, enum
+// options are Option and set options []Option. Otherwise Old and New are
+// the values as decoded from JSON, references to other records being
+// replaced by their ID. Empty values are nil.
+type FieldChange struct {
+	Key  string      `json:"key"`
+	Name string      `json:"name,omitempty"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+func (c FieldChange) String() string {
+	return Stringify(c)
+}
+
+// Changes lists the fields differing between two versions of a record,
+// sorted by key.
+type Changes []FieldChange
+
+// Changed reports whether any of the fields with the given keys changed.
+func (c Changes) Changed(keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := c.Get(key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get returns the change of the field with the given key.
+func (c Changes) Get(key string) (FieldChange, bool) {
+	for _, change := range c {
+		if change.Key == key {
+			return change, true
+		}
+	}
+
+	return FieldChange{}, false
+}
+
+// Keys returns the keys of the changed fields.
+func (c Changes) Keys() []string {
+	keys := make([]string, len(c))
+
+	for i, change := range c {
+		keys[i] = change.Key
+	}
+
+	return keys
+}
+
+// DiffOptions specifices the optional parameters to the Diff function.
+type DiffOptions struct {
+	// Object is the type of the records compared. It defaults to the
+	// type of the records, it must be set to compare maps or raw JSON.
+	Object EventObject
+
+	// Metadata resolves field names and value types.
+	Metadata FieldMetadata
+
+	// Fields, if not nil, limits the comparison to the fields with these
+	// keys.
+	Fields []string
+
+	// Ignore lists more fields to ignore.
+	Ignore []string
+
+	// IncludeNoise reports changes of fields such as update_time and
+	// counters, which are ignored by default.
+	IncludeNoise bool
+}
+
+// Diff compares two versions of a record, such as the previous and current
+// objects of a webhook event, and returns the fields that changed. Records
+// are any entity type, pointers to one, maps or raw JSON; previous or
+// current may be nil. Custom fields are compared like other fields.
+func Diff(previous, current interface{}, opt *DiffOptions) (Changes, error) {
+	if opt == nil {
+		opt = &DiffOptions{}
+	}
+
+	object := opt.Object
+
+	if object == "" {
+		object, _ = recordObject(current)
+	}
+
+	if object == "" {
+		object, _ = recordObject(previous)
+	}
+
+	before, err := recordValues(previous)
+
+	if err != nil {
+		return nil, fmt.Errorf("previous record: %v", err)
+	}
+
+	after, err := recordValues(current)
+
+	if err != nil {
+		return nil, fmt.Errorf("current record: %v", err)
+	}
+
+	keys := map[string]bool{}
+
+	if opt.Fields != nil {
+		for _, key := range opt.Fields {
+			keys[key] = true
+		}
+	} else {
+		for key := range before {
+			keys[key] = true
+		}
+		for key := range after {
+			keys[key] = true
+		}
+	}
+
+	for _, key := range opt.Ignore {
+		delete(keys, key)
+	}
+
+	var changes Changes
+
+	for key := range keys {
+		if !opt.IncludeNoise && isDiffNoise(key) {
+			continue
+		}
+
+		field, known := opt.Metadata.ByKey(object, key)
+
+		oldValue := diffValue(before[key], field, known)
+		newValue := diffValue(after[key], field, known)
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, FieldChange{
+			Key:  key,
+			Name: field.Name,
+			Old:  oldValue,
+			New:  newValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes, nil
+}
+
+// diffValue normalizes a JSON decoded value so that equal values compare
+// equal, converting it to the type of field if known.
+func diffValue(value interface{}, field FieldDefinition, known bool) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	case map[string]interface{}:
+		// References to other records hold their ID under "value".
+		if id, ok := v["value"]; ok {
+			return diffValue(id, field, known)
+		}
+	}
+
+	if !known {
+		return value
+	}
+
+	text := optionID(value)
+
+	switch field.FieldType {
+	case FieldTypeDouble, FieldTypeMonetary:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+
+	case FieldTypeInt, FieldTypeUser, FieldTypeOrg, FieldTypePeople, FieldTypeStage:
+		if f, err := strconv.ParseFloat(text, 64); err == nil && f == float64(int(f)) {
+			return int(f)
+		}
+
+	case FieldTypeDate:
+		if t, err := time.Parse(DateTimeLayout, text); err == nil {
+			return Timestamp{t}
+		}
+
+		if t, err := time.Parse(DateLayout, text); err == nil {
+			return Date{t}
+		}
+
+	case FieldTypeEnum:
+		if option, ok := field.Option(text); ok {
+			return option
+		}
+
+	case FieldTypeSet:
+		var options []Option
+
+		for _, id := range strings.Split(text, ",") {
+			option, ok := field.Option(id)
+
+			if !ok {
+				return value
+			}
+
+			options = append(options, option)
+		}
+
+		return options
+	}
+
+	return value
+}
@@ -0,0 +1,70 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	var previous, current Deal
+
+	json.Unmarshal([]byte(`{"id": 1, "title": "Renewal", "stage_id": 1, "value": 100, "org_id": {"name": "Acme", "value": 5}, "update_time": "2026-01-01 00:00:00", "notes_count": 1, "a1b2c3": "7", "d4e5f6": null}`), &previous)
+	json.Unmarshal([]byte(`{"id": 1, "title": "Renewal", "stage_id": 2, "value": 100, "org_id": {"name": "Acme Inc", "value": 5}, "update_time": "2026-01-02 00:00:00", "notes_count": 2, "a1b2c3": "8", "d4e5f6": ""}`), &current)
+
+	metadata := FieldMetadata{
+		OBJECT_DEAL: {
+			{ID: 1, Key: "stage_id", Name: "Stage", FieldType: FieldTypeStage},
+			{ID: 2, Key: "a1b2c3", Name: "Segment", FieldType: FieldTypeEnum, Options: []Option{{ID: 7.0, Label: "SMB"}, {ID: 8.0, Label: "Enterprise"}}},
+		},
+	}
+
+	changes, err := Diff(previous, &current, &DiffOptions{Metadata: metadata})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := changes.Keys(); len(keys) != 2 || keys[0] != "a1b2c3" || keys[1] != "stage_id" {
+		t.Fatalf("Got changed fields %v", keys)
+	}
+
+	if !changes.Changed("stage_id") || changes.Changed("update_time", "notes_count", "org_id") {
+		t.Error("Got invalid Changed results")
+	}
+
+	stage, _ := changes.Get("stage_id")
+
+	if stage.Name != "Stage" || stage.Old != 1 || stage.New != 2 {
+		t.Errorf("Got invalid stage change %v", stage)
+	}
+
+	segment, _ := changes.Get("a1b2c3")
+
+	if segment.Old.(Option).Label != "SMB" || segment.New.(Option).Label != "Enterprise" {
+		t.Errorf("Got invalid custom field change %v", segment)
+	}
+
+	changes, err = Diff(previous, current, &DiffOptions{IncludeNoise: true, Ignore: []string{"a1b2c3"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !changes.Changed("update_time", "notes_count") || changes.Changed("a1b2c3") {
+		t.Errorf("Got changed fields %v", changes.Keys())
+	}
+}
+
+func TestDiff_Added(t *testing.T) {
+	changes, err := Diff(nil, &Note{ID: 3, Content: "Call back"}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, ok := changes.Get("content")
+
+	if !ok || content.Old != nil || content.New != "Call back" {
+		t.Errorf("Got invalid changes %v", changes)
+	}
+}
@@ -3,6 +3,7 @@ package pipedrive
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // RateLimitError occurs when Pipedrive returns 403 Forbidden response with a rate limit
@@ -30,3 +31,106 @@ func (e *ErrorResponse) Error() string {
 		e.Response.Request.Method, e.Response.Request.URL,
 		e.Response.StatusCode, e.Message)
 }
+
+// OrganizationHierarchyCycleError occurs when the parent relationships of
+// organizations form a cycle. Path lists the organization IDs along the cycle,
+// starting and ending with the same organization.
+type OrganizationHierarchyCycleError struct {
+	Path []int
+}
+
+func (e *OrganizationHierarchyCycleError) Error() string {
+	return fmt.Sprintf("organization hierarchy contains a cycle: %v", e.Path)
+}
+
+// FilterConditionError occurs when a filter condition refers to an unknown
+// field, or its operator or value do not suit the type of the field.
+type FilterConditionError struct {
+	Condition FilterCondition
+	Message   string
+}
+
+func (e *FilterConditionError) Error() string {
+	field := e.Condition.Key
+
+	if field == "" {
+		field = e.Condition.FieldID
+	}
+
+	return fmt.Sprintf("filter condition on %v field %q: %v", e.Condition.Object, field, e.Message)
+}
+
+// AmbiguousMatchError occurs when several records match the key of an
+// upsert whose policy is UPSERT_POLICY_ERROR.
+type AmbiguousMatchError struct {
+	Object EventObject
+	Field  string
+	Value  string
+	IDs    []int
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	return fmt.Sprintf("%d %v records match %v %q: %v", len(e.IDs), e.Object, e.Field, e.Value, e.IDs)
+}
+
+// BulkDeleteError occurs when some of the IDs of a bulk delete could not be
+// deleted. The result of the bulk delete tells why.
+type BulkDeleteError struct {
+	Object EventObject
+	IDs    []int
+}
+
+func (e *BulkDeleteError) Error() string {
+	return fmt.Sprintf("%d %v records could not be deleted: %v", len(e.IDs), e.Object, e.IDs)
+}
+
+// ValidationError occurs when the options of a create or update do not
+// suit the fields of the object. It lists every problem found.
+type ValidationError struct {
+	Object   EventObject
+	Problems []ValidationProblem
+}
+
+// ValidationProblem is a problem with the value of a field.
+type ValidationProblem struct {
+	Field   string
+	Message string
+}
+
+func (p ValidationProblem) String() string {
+	return fmt.Sprintf("%v: %v", p.Field, p.Message)
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+
+	for i, problem := range e.Problems {
+		problems[i] = problem.String()
+	}
+
+	return fmt.Sprintf("invalid %v: %v", e.Object, strings.Join(problems, "; "))
+}
+
+// SagaError occurs when a step of a saga fails. Orphans lists the records
+// created before which could not be deleted; the saga was fully compensated
+// if it is empty.
+type SagaError struct {
+	Step    string
+	Object  EventObject
+	Err     error
+	Orphans []SagaStepResult
+}
+
+func (e *SagaError) Error() string {
+	message := fmt.Sprintf("saga step %q creating %v failed: %v", e.Step, e.Object, e.Err)
+
+	if len(e.Orphans) > 0 {
+		message += fmt.Sprintf("; %d records could not be deleted", len(e.Orphans))
+	}
+
+	return message
+}
+
+func (e *SagaError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,504 @@
+package pipedrive
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Export formats.
+type ExportFormat string
+
+const (
+	// Newline delimited JSON, one object per record.
+	EXPORT_NDJSON ExportFormat = "ndjson"
+	// CSV with a header row.
+	EXPORT_CSV ExportFormat = "csv"
+)
+
+// exportObjects lists the objects that can be exported, and whether they
+// can be filtered.
+var exportObjects = map[EventObject]bool{
+	OBJECT_ACTIVITY:     true,
+	OBJECT_DEAL:         true,
+	OBJECT_FILE:         false,
+	OBJECT_NOTE:         false,
+	OBJECT_ORGANIZATION: true,
+	OBJECT_PERSON:       true,
+	OBJECT_PRODUCT:      true,
+	OBJECT_USER:         false,
+}
+
+// ExportOptions specifices the optional parameters to the Client.Export
+// method.
+type ExportOptions struct {
+	// Format defaults to EXPORT_NDJSON.
+	Format ExportFormat
+
+	// Columns selects the columns exported, in order, by key or by name.
+	// Nested values are selected by their key path, such as "org_id.name".
+	// By default the fields of the object are exported, followed by the
+	// other values of the first record.
+	Columns []string
+
+	// FilterID exports only the records matching a filter. Files, notes and
+	// users cannot be filtered.
+	FilterID int
+
+	// Metadata resolves field names and option labels. It is fetched when
+	// nil.
+	Metadata FieldMetadata
+
+	// FieldKeys names columns by field key rather than by field name.
+	FieldKeys bool
+}
+
+// Export writes all the records of object to w, one record at a time, and
+// returns the number of records written. Deals, persons, organizations,
+// activities, notes, products, files metadata and users can be exported.
+//
+// Records are flattened: references to other records, such as org_id,
+// become their ID and a "<key>.name" column, other nested objects a column
+// per value, and options of enum and set fields their label. Columns are
+// named after the field names, using field metadata.
+func (c *Client) Export(ctx context.Context, object EventObject, w io.Writer, opt *ExportOptions) (int, error) {
+	if opt == nil {
+		opt = &ExportOptions{}
+	}
+
+	filterable, ok := exportObjects[object]
+
+	if !ok {
+		return 0, fmt.Errorf("%q cannot be exported", object)
+	}
+
+	if opt.FilterID != 0 && !filterable {
+		return 0, fmt.Errorf("%q cannot be filtered", object)
+	}
+
+	format := opt.Format
+
+	if format == "" {
+		format = EXPORT_NDJSON
+	}
+
+	if format != EXPORT_NDJSON && format != EXPORT_CSV {
+		return 0, fmt.Errorf("unknown export format %q", format)
+	}
+
+	metadata := opt.Metadata
+
+	if metadata == nil {
+		metadata = FieldMetadata{}
+
+		if object != OBJECT_FILE && object != OBJECT_USER {
+			var err error
+
+			metadata, err = c.FieldMetadata(ctx, object)
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return 0, err
+	}
+
+	query := listQuery(object)
+
+	if opt.FilterID != 0 {
+		query.Set("filter_id", strconv.Itoa(opt.FilterID))
+	}
+
+	e := &exporter{
+		object:   object,
+		metadata: metadata,
+		names:    exportNames(metadata[object], opt.FieldKeys),
+	}
+
+	buffer := bufio.NewWriter(w)
+
+	var writer *csv.Writer
+
+	if format == EXPORT_CSV {
+		writer = csv.NewWriter(buffer)
+	}
+
+	paginator := c.NewPaginator(path, query)
+	count := 0
+
+	for paginator.Next(ctx) {
+		values, err := recordValues(paginator.Raw())
+
+		if err != nil {
+			return count, err
+		}
+
+		record := e.flatten(values)
+
+		if count == 0 {
+			e.columns = e.selectColumns(opt.Columns, record)
+
+			if writer != nil {
+				if err := writer.Write(e.header()); err != nil {
+					return count, err
+				}
+			}
+		}
+
+		if writer != nil {
+			row := make([]string, len(e.columns))
+
+			for i, column := range e.columns {
+				row[i] = exportText(record[column])
+			}
+
+			err = writer.Write(row)
+		} else {
+			err = e.writeJSON(buffer, record, opt.Columns == nil)
+		}
+
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if err := paginator.Err(); err != nil {
+		return count, err
+	}
+
+	if writer != nil {
+		if count == 0 {
+			e.columns = e.selectColumns(opt.Columns, map[string]interface{}{})
+
+			if err := writer.Write(e.header()); err != nil {
+				return count, err
+			}
+		}
+
+		writer.Flush()
+
+		if err := writer.Error(); err != nil {
+			return count, err
+		}
+	}
+
+	return count, buffer.Flush()
+}
+
+type exporter struct {
+	object   EventObject
+	metadata FieldMetadata
+	names    map[string]string
+	columns  []string
+}
+
+// exportNames maps the keys of fields to unique column names.
+func exportNames(fields []FieldDefinition, keys bool) map[string]string {
+	names := map[string]string{}
+	counts := map[string]int{}
+
+	for _, field := range fields {
+		counts[field.Name]++
+	}
+
+	for _, field := range fields {
+		switch {
+		case keys || field.Name == "":
+			names[field.Key] = field.Key
+		case counts[field.Name] > 1:
+			names[field.Key] = fmt.Sprintf("%s (%s)", field.Name, field.Key)
+		default:
+			names[field.Key] = field.Name
+		}
+	}
+
+	return names
+}
+
+// name returns the name of a column, nested values being named after the
+// field they belong to.
+func (e *exporter) name(column string) string {
+	if name, ok := e.names[column]; ok {
+		return name
+	}
+
+	if i := strings.Index(column, "."); i > 0 {
+		if name, ok := e.names[column[:i]]; ok {
+			return name + " " + column[i+1:]
+		}
+	}
+
+	return column
+}
+
+// header returns the names of the columns.
+func (e *exporter) header() []string {
+	header := make([]string, len(e.columns))
+
+	for i, column := range e.columns {
+		header[i] = e.name(column)
+	}
+
+	return header
+}
+
+// selectColumns returns the keys of the columns selected, or of the default
+// columns given the first record.
+func (e *exporter) selectColumns(selected []string, record map[string]interface{}) []string {
+	var keys []string
+	known := map[string]bool{}
+
+	for _, field := range e.metadata[e.object] {
+		keys = append(keys, field.Key)
+		known[field.Key] = true
+
+		switch field.FieldType {
+		case FieldTypeOrg, FieldTypePeople, FieldTypeUser:
+			if _, ok := record[field.Key+".name"]; !ok {
+				record[field.Key+".name"] = nil
+			}
+		}
+	}
+
+	var extra []string
+
+	for key := range record {
+		if !known[key] {
+			extra = append(extra, key)
+		}
+	}
+
+	sort.Strings(extra)
+
+	// Nested values follow the field they belong to.
+	var columns []string
+
+	for _, key := range keys {
+		columns = append(columns, key)
+
+		for _, nested := range extra {
+			if strings.HasPrefix(nested, key+".") {
+				columns = append(columns, nested)
+				known[nested] = true
+			}
+		}
+	}
+
+	for _, key := range extra {
+		if !known[key] {
+			columns = append(columns, key)
+		}
+	}
+
+	if selected == nil {
+		return columns
+	}
+
+	isColumn := map[string]bool{}
+	byName := map[string]string{}
+
+	for _, column := range columns {
+		isColumn[column] = true
+		byName[e.name(column)] = column
+	}
+
+	var result []string
+
+	for _, column := range selected {
+		if key, ok := byName[column]; ok && !isColumn[column] {
+			column = key
+		}
+
+		result = append(result, column)
+	}
+
+	return result
+}
+
+// writeJSON writes record as a line of JSON, with the selected columns or,
+// if all is set, with all its values.
+func (e *exporter) writeJSON(w io.Writer, record map[string]interface{}, all bool) error {
+	columns := e.columns
+
+	if all {
+		included := map[string]bool{}
+
+		for _, column := range columns {
+			included[column] = true
+		}
+
+		var extra []string
+
+		for key := range record {
+			if !included[key] {
+				extra = append(extra, key)
+			}
+		}
+
+		sort.Strings(extra)
+		columns = append(columns[:len(columns):len(columns)], extra...)
+	}
+
+	var b strings.Builder
+
+	b.WriteByte('{')
+
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		name, err := json.Marshal(e.name(column))
+
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(record[column])
+
+		if err != nil {
+			return err
+		}
+
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// flatten returns the values of a record keyed by column.
+func (e *exporter) flatten(values map[string]interface{}) map[string]interface{} {
+	record := map[string]interface{}{}
+
+	for key, value := range values {
+		e.flattenValue(record, key, value)
+	}
+
+	return record
+}
+
+func (e *exporter) flattenValue(record map[string]interface{}, column string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// References to other records hold their ID under "value".
+		if id, ok := v["value"]; ok {
+			record[column] = id
+
+			if name, ok := v["name"]; ok {
+				record[column+".name"] = name
+			}
+
+			return
+		}
+
+		for key, nested := range v {
+			e.flattenValue(record, column+"."+key, nested)
+		}
+
+	case []interface{}:
+		// Lists of emails and phones hold their values under "value".
+		values := make([]interface{}, 0, len(v))
+
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				if value, ok := m["value"]; ok {
+					values = append(values, value)
+					continue
+				}
+			}
+
+			values = append(values, item)
+		}
+
+		record[column] = values
+
+	default:
+		record[column] = e.label(column, value)
+	}
+}
+
+// label replaces the option IDs of enum and set fields by their label.
+func (e *exporter) label(key string, value interface{}) interface{} {
+	field, ok := e.metadata.ByKey(e.object, key)
+
+	if !ok || value == nil {
+		return value
+	}
+
+	text := optionID(value)
+
+	switch field.FieldType {
+	case FieldTypeEnum:
+		if option, ok := field.Option(text); ok {
+			return option.Label
+		}
+
+	case FieldTypeSet:
+		var labels []interface{}
+
+		for _, id := range strings.Split(text, ",") {
+			option, ok := field.Option(id)
+
+			if !ok {
+				return value
+			}
+
+			labels = append(labels, option.Label)
+		}
+
+		return labels
+	}
+
+	return value
+}
+
+// exportText formats a value for CSV.
+func exportText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		texts := make([]string, len(v))
+
+		for i, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				b, _ := json.Marshal(item)
+				texts[i] = string(b)
+			default:
+				texts[i] = exportText(item)
+			}
+		}
+
+		return strings.Join(texts, ", ")
+	}
+
+	b, _ := json.Marshal(value)
+
+	return string(b)
+}
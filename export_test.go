@@ -0,0 +1,102 @@
+package pipedrive
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Export(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/deals" || r.URL.Query().Get("filter_id") != "9" {
+			t.Errorf("Got unexpected request %s", r.URL)
+		}
+
+		switch r.URL.Query().Get("start") {
+		case "":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 1, "title": "Renewal, 2026", "org_id": {"name": "Acme", "value": 5}, "a1b2c3": "7", "d4e5f6": "7,8"}
+			], "additional_data": {"pagination": {"more_items_in_collection": true, "next_start": 1}}}`))
+		case "1":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 2, "title": "Upsell", "org_id": null, "a1b2c3": null, "d4e5f6": null}
+			], "additional_data": {"pagination": {"more_items_in_collection": false}}}`))
+		}
+	}))
+
+	metadata := FieldMetadata{
+		OBJECT_DEAL: {
+			{ID: 1, Key: "id", Name: "ID", FieldType: FieldTypeInt},
+			{ID: 2, Key: "title", Name: "Title", FieldType: FieldTypeVarchar},
+			{ID: 3, Key: "org_id", Name: "Organization", FieldType: FieldTypeOrg},
+			{ID: 4, Key: "a1b2c3", Name: "Segment", FieldType: FieldTypeEnum, Options: []Option{{ID: 7.0, Label: "SMB"}}},
+			{ID: 5, Key: "d4e5f6", Name: "Regions", FieldType: FieldTypeSet, Options: []Option{{ID: 7.0, Label: "EMEA"}, {ID: 8.0, Label: "APAC"}}},
+		},
+	}
+
+	var b bytes.Buffer
+
+	count, err := client.Export(context.Background(), OBJECT_DEAL, &b, &ExportOptions{
+		Format:   EXPORT_CSV,
+		FilterID: 9,
+		Metadata: metadata,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `ID,Title,Organization,Organization name,Segment,Regions
+1,"Renewal, 2026",5,Acme,SMB,"EMEA, APAC"
+2,Upsell,,,,
+`
+
+	if count != 2 || b.String() != want {
+		t.Errorf("Got %d records:\n%s\nwant:\n%s", count, b.String(), want)
+	}
+
+	b.Reset()
+
+	_, err = client.Export(context.Background(), OBJECT_DEAL, &b, &ExportOptions{
+		Columns:  []string{"Title", "org_id.name", "d4e5f6"},
+		FilterID: 9,
+		Metadata: metadata,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `{"Title":"Renewal, 2026","Organization name":"Acme","Regions":["EMEA","APAC"]}
+{"Title":"Upsell","Organization name":null,"Regions":null}
+`
+
+	if b.String() != want {
+		t.Errorf("Got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestClient_ExportActivities(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/activities" || r.URL.Query().Get("user_id") != "0" {
+			t.Errorf("Got unexpected request %s, want activities of every user", r.URL)
+		}
+
+		w.Write([]byte(`{"success": true, "data": [{"id": 1, "subject": "Call"}]}`))
+	}))
+
+	var b bytes.Buffer
+
+	count, err := client.Export(context.Background(), OBJECT_ACTIVITY, &b, &ExportOptions{
+		Metadata: FieldMetadata{OBJECT_ACTIVITY: {{ID: 1, Key: "subject", Name: "Subject", FieldType: FieldTypeVarchar}}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Errorf("Got %d records, want 1", count)
+	}
+}
@@ -0,0 +1,141 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// FieldDefinition describes a field of an object independently of the
+// object it belongs to.
+type FieldDefinition struct {
+	ID        int       `json:"id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+	Options   []Option  `json:"options,omitempty"`
+
+	// EditFlag is set for custom fields, whose definition can be edited.
+	EditFlag        bool `json:"edit_flag,omitempty"`
+	BulkEditAllowed bool `json:"bulk_edit_allowed,omitempty"`
+	Mandatory       bool `json:"mandatory,omitempty"`
+}
+
+func (f FieldDefinition) String() string {
+	return Stringify(f)
+}
+
+// Option returns the option of an enum or set field matching id, which may
+// be given as an int or a string.
+func (f FieldDefinition) Option(id interface{}) (Option, bool) {
+	want := fmt.Sprint(id)
+
+	for _, option := range f.Options {
+		if optionID(option.ID) == want {
+			return option, true
+		}
+	}
+
+	return Option{}, false
+}
+
+// OptionByLabel returns the option of an enum or set field labeled label.
+func (f FieldDefinition) OptionByLabel(label string) (Option, bool) {
+	for _, option := range f.Options {
+		if option.Label == label {
+			return option, true
+		}
+	}
+
+	return Option{}, false
+}
+
+// optionID formats an option ID, which is decoded as a float64 or a string.
+func optionID(id interface{}) string {
+	switch v := id.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FieldMetadata holds the field definitions of one or more objects.
+type FieldMetadata map[EventObject][]FieldDefinition
+
+// ByKey returns the definition of the field of object with the given key.
+func (m FieldMetadata) ByKey(object EventObject, key string) (FieldDefinition, bool) {
+	for _, field := range m[object] {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return FieldDefinition{}, false
+}
+
+// ByID returns the definition of the field of object with the given ID.
+func (m FieldMetadata) ByID(object EventObject, id int) (FieldDefinition, bool) {
+	for _, field := range m[object] {
+		if field.ID == id {
+			return field, true
+		}
+	}
+
+	return FieldDefinition{}, false
+}
+
+// FieldMetadata fetches the field definitions of the given objects. Deals,
+// persons, organizations, products, activities and notes have fields.
+func (c *Client) FieldMetadata(ctx context.Context, objects ...EventObject) (FieldMetadata, error) {
+	metadata := FieldMetadata{}
+
+	for _, object := range objects {
+		fields, err := c.fieldDefinitions(ctx, object)
+
+		if err != nil {
+			return nil, err
+		}
+
+		metadata[object] = fields
+	}
+
+	return metadata, nil
+}
+
+func (c *Client) fieldDefinitions(ctx context.Context, object EventObject) ([]FieldDefinition, error) {
+	api, err := c.Fields(object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := api.ListFields(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]FieldDefinition, len(fields))
+
+	for i, field := range fields {
+		definitions[i] = field.Definition()
+	}
+
+	return definitions, nil
+}
+
+// mandatory reports whether a mandatory flag is set. Deal fields flag
+// fields mandatory with a boolean, or with an object holding conditions.
+func mandatory(flag interface{}) bool {
+	switch v := flag.(type) {
+	case bool:
+		return v
+	case nil:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,319 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FieldOptionRemoval reports the removal of an option of an enum or set
+// field.
+type FieldOptionRemoval struct {
+	// Field is the field without the option.
+	Field FieldDefinition `json:"field"`
+
+	// Migrated lists the IDs of the records moved to the option migrated
+	// to.
+	Migrated []int `json:"migrated,omitempty"`
+}
+
+func (r FieldOptionRemoval) String() string {
+	return Stringify(r)
+}
+
+// The options of enum and set fields are replaced as a whole by updates,
+// options left out being deleted along with the values of the records
+// using them. The methods below send every option with its ID, so only the
+// option edited changes.
+
+// getOptionField returns the enum or set field of object with the given ID.
+func (c *Client) getOptionField(ctx context.Context, object EventObject, id int) (*FieldDefinition, error) {
+	if _, ok := fieldCollections[object]; !ok {
+		return nil, fmt.Errorf("%s fields cannot be edited", object)
+	}
+
+	field, err := c.getField(ctx, object, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if field.FieldType != FieldTypeEnum && field.FieldType != FieldTypeSet {
+		return nil, fmt.Errorf("%s field %q is a %s field, not an enum or set field", object, field.Name, field.FieldType)
+	}
+
+	definition := field.Definition()
+
+	return &definition, nil
+}
+
+// updateFieldOptions replaces the options of a field.
+func (c *Client) updateFieldOptions(ctx context.Context, object EventObject, field *FieldDefinition, options []Option) (*FieldDefinition, error) {
+	updated, err := c.updateField(ctx, object, field.ID, &FieldUpdateOptions{Options: options})
+
+	if err != nil {
+		return nil, err
+	}
+
+	definition := updated.Definition()
+
+	return &definition, nil
+}
+
+// addFieldOption adds an option to the enum or set field of object with
+// the given ID.
+func (c *Client) addFieldOption(ctx context.Context, object EventObject, id int, label string) (*FieldDefinition, error) {
+	field, err := c.getOptionField(ctx, object, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := field.OptionByLabel(label); ok {
+		return nil, fmt.Errorf("%s field %q already has an option %q", object, field.Name, label)
+	}
+
+	return c.updateFieldOptions(ctx, object, field, append(field.Options, Option{Label: label}))
+}
+
+// renameFieldOption renames an option of the enum or set field of object
+// with the given ID.
+func (c *Client) renameFieldOption(ctx context.Context, object EventObject, id int, optionID int, label string) (*FieldDefinition, error) {
+	field, err := c.getOptionField(ctx, object, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := field.Option(optionID); !ok {
+		return nil, fmt.Errorf("%s field %q has no option %d", object, field.Name, optionID)
+	}
+
+	if option, ok := field.OptionByLabel(label); ok && refID(option.ID) != optionID {
+		return nil, fmt.Errorf("%s field %q already has an option %q", object, field.Name, label)
+	}
+
+	options := make([]Option, len(field.Options))
+
+	for i, option := range field.Options {
+		options[i] = option
+
+		if refID(option.ID) == optionID {
+			options[i].Label = label
+		}
+	}
+
+	return c.updateFieldOptions(ctx, object, field, options)
+}
+
+// reorderFieldOptions orders the options of the enum or set field of object
+// with the given ID as optionIDs, which must list every option.
+func (c *Client) reorderFieldOptions(ctx context.Context, object EventObject, id int, optionIDs []int) (*FieldDefinition, error) {
+	field, err := c.getOptionField(ctx, object, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(optionIDs) != len(field.Options) {
+		return nil, fmt.Errorf("%s field %q has %d options, %d ordered", object, field.Name, len(field.Options), len(optionIDs))
+	}
+
+	options := make([]Option, 0, len(optionIDs))
+	seen := map[int]bool{}
+
+	for _, optionID := range optionIDs {
+		option, ok := field.Option(optionID)
+
+		if !ok || seen[optionID] {
+			return nil, fmt.Errorf("%s field %q: option %d is unknown or listed twice", object, field.Name, optionID)
+		}
+
+		seen[optionID] = true
+		options = append(options, option)
+	}
+
+	return c.updateFieldOptions(ctx, object, field, options)
+}
+
+// removeFieldOption removes an option of the enum or set field of object
+// with the given ID, first moving the records using it to the option
+// migrateTo, unless 0.
+func (c *Client) removeFieldOption(ctx context.Context, object EventObject, id int, optionID int, migrateTo int) (*FieldOptionRemoval, error) {
+	field, err := c.getOptionField(ctx, object, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := field.Option(optionID); !ok {
+		return nil, fmt.Errorf("%s field %q has no option %d", object, field.Name, optionID)
+	}
+
+	if len(field.Options) == 1 {
+		return nil, fmt.Errorf("%s field %q: option %d is the last option and cannot be removed", object, field.Name, optionID)
+	}
+
+	if migrateTo == optionID {
+		return nil, fmt.Errorf("%s field %q: option %d cannot be migrated to itself", object, field.Name, optionID)
+	}
+
+	if _, ok := field.Option(migrateTo); migrateTo != 0 && !ok {
+		return nil, fmt.Errorf("%s field %q has no option %d", object, field.Name, migrateTo)
+	}
+
+	removal := &FieldOptionRemoval{}
+
+	if migrateTo != 0 {
+		migrated, err := c.migrateFieldOption(ctx, object, field, optionID, migrateTo)
+
+		removal.Migrated = migrated
+
+		if err != nil {
+			return removal, err
+		}
+	}
+
+	options := make([]Option, 0, len(field.Options)-1)
+
+	for _, option := range field.Options {
+		if refID(option.ID) != optionID {
+			options = append(options, option)
+		}
+	}
+
+	updated, err := c.updateFieldOptions(ctx, object, field, options)
+
+	if err != nil {
+		return removal, err
+	}
+
+	removal.Field = *updated
+
+	return removal, nil
+}
+
+// migrateFieldOption moves the records of object using an option of a
+// field to the option migrateTo, returning the IDs of the records moved.
+func (c *Client) migrateFieldOption(ctx context.Context, object EventObject, field *FieldDefinition, optionID int, migrateTo int) ([]int, error) {
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := fmt.Sprint(optionID), fmt.Sprint(migrateTo)
+	operations := make(chan BatchOperation)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(operations)
+
+		paginator := c.NewPaginator(path, nil)
+
+		for paginator.Next(ctx) {
+			values, err := recordValues(paginator.Raw())
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			value, ok := migrateOptionValue(field.FieldType, values[field.Key], from, to)
+
+			if !ok {
+				continue
+			}
+
+			select {
+			case operations <- BatchOperation{
+				Type:   BATCH_UPDATE,
+				Object: object,
+				ID:     refID(values["id"]),
+				Data:   map[string]interface{}{field.Key: value},
+			}:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+
+		errs <- paginator.Err()
+	}()
+
+	var migrated []int
+	var failed []BatchResult
+
+	runner := c.NewBatchRunner()
+	runner.OnResult = func(result BatchResult) {
+		if result.Status == BATCH_OK {
+			migrated = append(migrated, result.ID)
+		} else {
+			failed = append(failed, result)
+		}
+	}
+
+	_, err = runner.Run(ctx, operations, nil)
+
+	// The operations are drained for the paginator to finish if the run
+	// stopped early.
+	for range operations {
+	}
+
+	if listErr := <-errs; listErr != nil && err == nil {
+		err = listErr
+	}
+
+	if err != nil {
+		return migrated, err
+	}
+
+	if len(failed) > 0 {
+		return migrated, fmt.Errorf("%s field %q: %d records could not be migrated to option %d, first: %s", object, field.Name, len(failed), migrateTo, failed[0].Error)
+	}
+
+	return migrated, nil
+}
+
+// migrateOptionValue returns the value of an enum or set field with option
+// from replaced by option to, and whether the value used option from. The
+// options of set fields are listed separated by commas.
+func migrateOptionValue(fieldType FieldType, value interface{}, from, to string) (interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	if fieldType == FieldTypeEnum {
+		if optionID(value) != from {
+			return nil, false
+		}
+
+		return to, true
+	}
+
+	var ids []string
+	found, present := false, false
+
+	for _, id := range strings.Split(optionID(value), ",") {
+		switch strings.TrimSpace(id) {
+		case from:
+			found = true
+		case to:
+			present = true
+			ids = append(ids, to)
+		case "":
+		default:
+			ids = append(ids, strings.TrimSpace(id))
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	if !present {
+		ids = append(ids, to)
+	}
+
+	return strings.Join(ids, ","), true
+}
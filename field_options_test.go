@@ -0,0 +1,108 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestDealFieldsService_RemoveOption(t *testing.T) {
+	var mu sync.Mutex
+	updates := map[string]interface{}{}
+	var options []map[string]interface{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/dealFields/5":
+			w.Write([]byte(`{"success": true, "data": {"id": 5, "key": "segment", "name": "Segment", "field_type": "set", "options": [{"id": 1, "label": "A"}, {"id": 2, "label": "B"}, {"id": 3, "label": "C"}]}}`))
+		case "GET /v1/dealFields/6":
+			w.Write([]byte(`{"success": true, "data": {"id": 6, "key": "tier", "name": "Tier", "field_type": "enum", "options": [{"id": 4, "label": "Gold"}]}}`))
+		case "GET /v1/deals":
+			w.Write([]byte(`{"success": true, "data": [{"id": 10, "segment": "1,2"}, {"id": 11, "segment": "3"}, {"id": 12, "segment": "2,3"}, {"id": 13, "segment": null}]}`))
+		case "PUT /v1/deals/10", "PUT /v1/deals/12":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			mu.Lock()
+			updates[r.URL.Path] = body["segment"]
+			mu.Unlock()
+
+			w.Write([]byte(`{"success": true, "data": {"id": ` + path.Base(r.URL.Path) + `}}`))
+		case "PUT /v1/dealFields/5":
+			var body struct {
+				Options []map[string]interface{} `json:"options"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			options = body.Options
+
+			w.Write([]byte(`{"success": true, "data": {"id": 5, "key": "segment", "name": "Segment", "field_type": "set", "options": [{"id": 1, "label": "A"}, {"id": 3, "label": "C"}]}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	removal, err := client.DealFields.RemoveOption(ctx, 5, 2, 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Ints(removal.Migrated)
+
+	if !reflect.DeepEqual(removal.Migrated, []int{10, 12}) || len(removal.Field.Options) != 2 {
+		t.Errorf("Got removal %v", removal)
+	}
+
+	if want := map[string]interface{}{"/v1/deals/10": "1,3", "/v1/deals/12": "3"}; !reflect.DeepEqual(updates, want) {
+		t.Errorf("Got updates %v, want %v", updates, want)
+	}
+
+	if want := []map[string]interface{}{{"id": 1.0, "label": "A"}, {"id": 3.0, "label": "C"}}; !reflect.DeepEqual(options, want) {
+		t.Errorf("Got options %v, want %v", options, want)
+	}
+
+	if _, err := client.DealFields.AddOption(ctx, 5, "A"); err == nil {
+		t.Error("Got no error adding an existing option")
+	}
+
+	if _, err := client.DealFields.ReorderOptions(ctx, 5, []int{3, 1}); err == nil {
+		t.Error("Got no error reordering without every option")
+	}
+
+	// The field is not updated, its option being kept otherwise.
+	if _, err := client.DealFields.RemoveOption(ctx, 6, 4, 0); err == nil {
+		t.Error("Got no error removing the last option")
+	}
+}
+
+func TestMigrateOptionValue(t *testing.T) {
+	tests := []struct {
+		fieldType FieldType
+		value     interface{}
+		want      interface{}
+		ok        bool
+	}{
+		{FieldTypeEnum, 2.0, "3", true},
+		{FieldTypeEnum, "1", nil, false},
+		{FieldTypeSet, "2", "3", true},
+		{FieldTypeSet, "1,2,4", "1,4,3", true},
+		{FieldTypeSet, "1, 4", nil, false},
+		{FieldTypeSet, nil, nil, false},
+	}
+
+	for _, test := range tests {
+		got, ok := migrateOptionValue(test.fieldType, test.value, "2", "3")
+
+		if got != test.want || ok != test.ok {
+			t.Errorf("migrateOptionValue(%v, %v) = %v, %v, want %v, %v", test.fieldType, test.value, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// EntityField represents a Pipedrive field of any entity, such as a deal
+// field or a person field. The fields of each entity, such as DealField, are
+// aliases of it.
+type EntityField struct {
+	ID                        int          `json:"id"`
+	Key                       string       `json:"key"`
+	Name                      string       `json:"name"`
+	OrderNr                   int          `json:"order_nr,omitempty"`
+	PicklistData              PicklistData `json:"picklist_data,omitempty"`
+	FieldType                 FieldType    `json:"field_type"`
+	AddTime                   string       `json:"add_time,omitempty"`
+	UpdateTime                string       `json:"update_time,omitempty"`
+	ActiveFlag                bool         `json:"active_flag"`
+	EditFlag                  bool         `json:"edit_flag"`
+	IndexVisibleFlag          bool         `json:"index_visible_flag,omitempty"`
+	DetailsVisibleFlag        bool         `json:"details_visible_flag,omitempty"`
+	AddVisibleFlag            bool         `json:"add_visible_flag,omitempty"`
+	ImportantFlag             bool         `json:"important_flag,omitempty"`
+	BulkEditAllowed           bool         `json:"bulk_edit_allowed,omitempty"`
+	SearchableFlag            bool         `json:"searchable_flag,omitempty"`
+	FilteringAllowed          bool         `json:"filtering_allowed,omitempty"`
+	SortableFlag              bool         `json:"sortable_flag,omitempty"`
+	VisibleInExportsFlag      bool         `json:"visible_in_exports_flag,omitempty"`
+	UseField                  string       `json:"use_field,omitempty"`
+	Link                      string       `json:"link,omitempty"`
+	DisplayField              string       `json:"display_field,omitempty"`
+	Autocomplete              string       `json:"autocomplete,omitempty"`
+	IsSubfield                bool         `json:"is_subfield,omitempty"`
+	Options                   []Option     `json:"options,omitempty"`
+	BulkEditAllowedConditions *struct {
+		Status string `json:"status"`
+	} `json:"bulk_edit_allowed_conditions,omitempty"`
+
+	// MandatoryFlag is set if the field is mandatory, unconditionally or
+	// under the conditions of MandatoryConditions.
+	MandatoryFlag bool `json:"mandatory_flag"`
+
+	// MandatoryConditions holds the conditions making a deal field
+	// mandatory, such as the pipelines in which it is, sent by the API in
+	// place of the mandatory flag.
+	MandatoryConditions map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes a field, whose mandatory flag is a boolean or an
+// object holding conditions.
+func (f *EntityField) UnmarshalJSON(b []byte) error {
+	type entityField EntityField
+
+	var raw struct {
+		*entityField
+		MandatoryFlag interface{} `json:"mandatory_flag"`
+	}
+
+	raw.entityField = (*entityField)(f)
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	f.MandatoryFlag = mandatory(raw.MandatoryFlag)
+	f.MandatoryConditions, _ = raw.MandatoryFlag.(map[string]interface{})
+
+	return nil
+}
+
+// MarshalJSON encodes a field, its mandatory flag holding its conditions if
+// any, as sent by the API.
+func (f EntityField) MarshalJSON() ([]byte, error) {
+	type entityField EntityField
+
+	raw := struct {
+		entityField
+		MandatoryFlag interface{} `json:"mandatory_flag"`
+	}{entityField(f), f.MandatoryFlag}
+
+	if f.MandatoryConditions != nil {
+		raw.MandatoryFlag = f.MandatoryConditions
+	}
+
+	return json.Marshal(raw)
+}
+
+func (f EntityField) String() string {
+	return Stringify(f)
+}
+
+// Mandatory reports whether the field is mandatory, unconditionally or
+// under conditions.
+func (f EntityField) Mandatory() bool {
+	return f.MandatoryFlag
+}
+
+// Definition returns the definition of the field.
+func (f EntityField) Definition() FieldDefinition {
+	return FieldDefinition{
+		ID:              f.ID,
+		Key:             f.Key,
+		Name:            f.Name,
+		FieldType:       f.FieldType,
+		Options:         f.Options,
+		EditFlag:        f.EditFlag,
+		BulkEditAllowed: f.BulkEditAllowed,
+		Mandatory:       f.Mandatory(),
+	}
+}
+
+// PicklistData holds the settings of the picklist of a field, whose keys
+// vary by field.
+type PicklistData map[string]interface{}
+
+// UnmarshalJSON decodes picklist data, ignoring values which are not
+// objects.
+func (p *PicklistData) UnmarshalJSON(b []byte) error {
+	var data map[string]interface{}
+
+	if err := json.Unmarshal(b, &data); err != nil {
+		*p = nil
+		return nil
+	}
+
+	*p = data
+
+	return nil
+}
+
+// EntityFieldsResponse represents multiple fields response.
+type EntityFieldsResponse struct {
+	Success        bool           `json:"success"`
+	Data           []EntityField  `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// EntityFieldResponse represents single field response.
+type EntityFieldResponse struct {
+	Success        bool           `json:"success"`
+	Data           EntityField    `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// FieldCreateOptions specifices the optional parameters to the
+// FieldsAPI.CreateField method.
+type FieldCreateOptions struct {
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+
+	// Options are the labels of the options of enum and set fields.
+	Options []string `json:"-"`
+
+	AddVisibleFlag *bool `json:"add_visible_flag,omitempty"`
+}
+
+// FieldUpdateOptions specifices the optional parameters to the
+// FieldsAPI.UpdateField method.
+type FieldUpdateOptions struct {
+	Name string `json:"name,omitempty"`
+
+	// Options replace the options of enum and set fields. Existing options
+	// must be given with their ID, options left out being deleted; new
+	// options only need a label.
+	Options []Option `json:"-"`
+
+	AddVisibleFlag *bool `json:"add_visible_flag,omitempty"`
+}
+
+// FieldsAPI manages the fields of an entity. It is implemented by the
+// services of each entity's fields, and returned for an entity by
+// Client.Fields.
+type FieldsAPI interface {
+	// ListFields returns all fields.
+	ListFields(ctx context.Context) ([]EntityField, error)
+
+	// GetField returns the field with the given ID.
+	GetField(ctx context.Context, id int) (*EntityField, error)
+
+	// CreateField creates a custom field.
+	CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error)
+
+	// UpdateField updates a custom field.
+	UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error)
+
+	// DeleteField deletes a custom field.
+	DeleteField(ctx context.Context, id int) error
+}
+
+// fieldPaths maps objects to the path of the collection endpoint of their
+// fields. Only the fields listed by fieldCollections can be edited.
+var fieldPaths = map[EventObject]string{
+	OBJECT_ACTIVITY:     "/activityFields",
+	OBJECT_DEAL:         "/dealFields",
+	OBJECT_NOTE:         "/noteFields",
+	OBJECT_ORGANIZATION: "/organizationFields",
+	OBJECT_PERSON:       "/personFields",
+	OBJECT_PRODUCT:      "/productFields",
+}
+
+// Fields returns the fields API of object, such as OBJECT_DEAL.
+func (c *Client) Fields(object EventObject) (FieldsAPI, error) {
+	switch object {
+	case OBJECT_ACTIVITY:
+		return c.ActivityFields, nil
+	case OBJECT_DEAL:
+		return c.DealFields, nil
+	case OBJECT_NOTE:
+		return c.NoteFields, nil
+	case OBJECT_ORGANIZATION:
+		return c.OrganizationField, nil
+	case OBJECT_PERSON:
+		return c.PersonFields, nil
+	case OBJECT_PRODUCT:
+		return c.ProductFields, nil
+	}
+
+	return nil, fmt.Errorf("object %q has no fields", object)
+}
+
+// fieldPath returns the path of the field of object with the given ID, or
+// of the collection if id is 0. If editable is set, the fields of object
+// must be editable.
+func fieldPath(object EventObject, id int, editable bool) (string, error) {
+	path, ok := fieldPaths[object]
+
+	if !ok {
+		return "", fmt.Errorf("object %q has no fields", object)
+	}
+
+	if _, ok := fieldCollections[object]; editable && !ok {
+		return "", fmt.Errorf("%s fields cannot be edited", object)
+	}
+
+	if id != 0 {
+		path = fmt.Sprintf("%s/%d", path, id)
+	}
+
+	return path, nil
+}
+
+func (c *Client) listFields(ctx context.Context, object EventObject) ([]EntityField, error) {
+	path, err := fieldPath(object, 0, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []EntityField
+
+	paginator := c.NewPaginator(path, nil)
+
+	for paginator.Next(ctx) {
+		var field EntityField
+
+		if err := paginator.Decode(&field); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, paginator.Err()
+}
+
+func (c *Client) getField(ctx context.Context, object EventObject, id int) (*EntityField, error) {
+	path, err := fieldPath(object, id, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Activity and note fields cannot be fetched one by one.
+	if _, ok := fieldCollections[object]; !ok {
+		fields, err := c.listFields(ctx, object)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range fields {
+			if field.ID == id {
+				return &field, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%s field %d not found", object, id)
+	}
+
+	return c.doField(ctx, http.MethodGet, path, nil)
+}
+
+func (c *Client) createField(ctx context.Context, object EventObject, opt *FieldCreateOptions) (*EntityField, error) {
+	path, err := fieldPath(object, 0, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opt == nil {
+		opt = &FieldCreateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doField(ctx, http.MethodPost, path, body)
+}
+
+func (c *Client) updateField(ctx context.Context, object EventObject, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	path, err := fieldPath(object, id, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opt == nil {
+		opt = &FieldUpdateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doField(ctx, http.MethodPut, path, body)
+}
+
+func (c *Client) deleteField(ctx context.Context, object EventObject, id int) error {
+	path, err := fieldPath(object, id, true)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := c.NewRequest(http.MethodDelete, path, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(ctx, req, nil)
+
+	return err
+}
+
+// fieldBody returns the body of a field create or update, adding the
+// options, as labels or as Options. Options without ID are sent with their
+// label only.
+func fieldBody(opt interface{}, options interface{}) (map[string]interface{}, error) {
+	body, err := recordValues(opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch options := options.(type) {
+	case []string:
+		if options == nil {
+			break
+		}
+
+		list := make([]map[string]interface{}, len(options))
+
+		for i, label := range options {
+			list[i] = map[string]interface{}{"label": label}
+		}
+
+		body["options"] = list
+
+	case []Option:
+		if options == nil {
+			break
+		}
+
+		list := make([]map[string]interface{}, len(options))
+
+		for i, option := range options {
+			list[i] = map[string]interface{}{"label": option.Label}
+
+			if option.ID != nil {
+				list[i]["id"] = option.ID
+			}
+		}
+
+		body["options"] = list
+	}
+
+	return body, nil
+}
+
+func (c *Client) doField(ctx context.Context, method, path string, body interface{}) (*EntityField, error) {
+	req, err := c.NewRequest(method, path, nil, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var record *EntityFieldResponse
+
+	if _, err := c.Do(ctx, req, &record); err != nil {
+		return nil, err
+	}
+
+	return &record.Data, nil
+}
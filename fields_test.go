@@ -0,0 +1,97 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Fields(t *testing.T) {
+	var created map[string]interface{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/productFields":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 1, "key": "name", "name": "Name", "field_type": "varchar", "mandatory_flag": true, "picklist_data": false},
+				{"id": 2, "key": "size", "name": "Size", "field_type": "enum", "edit_flag": true, "options": [{"id": 7, "label": "S"}], "picklist_data": {"sort": "asc"}}
+			]}`))
+		case "GET /v1/dealFields/3":
+			w.Write([]byte(`{"success": true, "data": {"id": 3, "key": "stage_id", "name": "Stage", "field_type": "stage", "mandatory_flag": {"pipeline_ids": [1]}}}`))
+		case "POST /v1/personFields":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"success": true, "data": {"id": 4, "key": "tier", "name": "Tier", "field_type": "enum"}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	products, err := client.Fields(OBJECT_PRODUCT)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := products.ListFields(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 2 || !fields[0].Mandatory() || fields[0].PicklistData != nil {
+		t.Fatalf("Got fields %v", fields)
+	}
+
+	if option, ok := fields[1].Definition().Option(7); !ok || option.Label != "S" || fields[1].PicklistData["sort"] != "asc" {
+		t.Errorf("Got field %v", fields[1])
+	}
+
+	field, err := client.DealFields.GetField(ctx, 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !field.MandatoryFlag || field.MandatoryConditions["pipeline_ids"] == nil {
+		t.Errorf("Got field %v, want it mandatory under conditions", field)
+	}
+
+	var decoded EntityField
+
+	if b, err := json.Marshal(field); err != nil || json.Unmarshal(b, &decoded) != nil || !reflect.DeepEqual(&decoded, field) {
+		t.Errorf("Got field %v round-tripping %v", decoded, field)
+	}
+
+	if _, err := client.PersonFields.CreateField(ctx, &FieldCreateOptions{Name: "Tier", FieldType: FieldTypeEnum, Options: []string{"Gold"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"name":       "Tier",
+		"field_type": "enum",
+		"options":    []interface{}{map[string]interface{}{"label": "Gold"}},
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("Got body %v, want %v", created, want)
+	}
+
+	created = nil
+
+	if _, _, err := client.PersonFields.Create(ctx, &PersonFieldCreateOptions{Name: "Tier", FieldType: FieldTypeEnum, Options: []string{"Gold"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("Got body %v, want %v", created, want)
+	}
+
+	if _, err := client.ActivityFields.CreateField(ctx, &FieldCreateOptions{Name: "Tier"}); err == nil {
+		t.Error("Got no error creating an activity field")
+	}
+}
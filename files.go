@@ -1,13 +1,16 @@
 package pipedrive
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"io/ioutil"
-	"mime/multipart"
+	"io"
+	"mime"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 )
 
 // FilesService handles files related
@@ -124,7 +127,8 @@ func (s *FilesService) GetDownloadLinkByID(id int) (string, *http.Request, error
 	return string(req.URL.Scheme + "://" + req.URL.Host + req.URL.Path), req, nil
 }
 
-// Upload a file.
+// Upload a file from disk. The file name is taken from fileName and the
+// content type from its extension.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Files/post_files
 func (s *FilesService) Upload(ctx context.Context, fileName string, filePath string) (*FileResponse, *Response, error) {
@@ -136,40 +140,95 @@ func (s *FilesService) Upload(ctx context.Context, fileName string, filePath str
 
 	defer file.Close()
 
-	fileContents, err := ioutil.ReadAll(file)
+	fileInfo, err := file.Stat()
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	fileInfo, err := file.Stat()
-
-	if err != nil {
-		return nil, nil, err
+	if fileName == "" {
+		fileName = fileInfo.Name()
 	}
 
-	var body *bytes.Buffer
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile(fileName, fileInfo.Name())
+	return s.UploadReader(ctx, file, &FileUploadOptions{
+		FileName:    fileName,
+		ContentType: mime.TypeByExtension(filepath.Ext(fileName)),
+		Size:        fileInfo.Size(),
+	})
+}
 
-	if err != nil {
-		return nil, nil, err
-	}
+// FileUploadOptions specifices the parameters to the
+// FilesService.UploadReader method.
+type FileUploadOptions struct {
+	FileName    string
+	ContentType string
+
+	// Size of the file in bytes, or zero if unknown.
+	Size int64
+
+	DealID     uint
+	PersonID   uint
+	OrgID      uint
+	ActivityID uint
+	ProductID  uint
+	LeadID     string
+
+	// Progress, if set, is called as the file is sent with the number of bytes
+	// read so far and the total size, which is zero if unknown.
+	Progress func(sent int64, total int64)
+}
 
-	part.Write(fileContents)
+// UploadReader uploads a file streamed from r and links it to the items set
+// in opt. The upload stops with an error when ctx is canceled. If r is an
+// io.Closer it is closed once the upload ends.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Files/post_files
+func (s *FilesService) UploadReader(ctx context.Context, r io.Reader, opt *FileUploadOptions) (*FileResponse, *Response, error) {
+	if opt == nil {
+		opt = &FileUploadOptions{}
+	}
 
-	err = writer.Close()
+	form := map[string]string{}
 
-	if err != nil {
-		return nil, nil, err
+	if opt.DealID != 0 {
+		form["deal_id"] = strconv.FormatUint(uint64(opt.DealID), 10)
+	}
+	if opt.PersonID != 0 {
+		form["person_id"] = strconv.FormatUint(uint64(opt.PersonID), 10)
+	}
+	if opt.OrgID != 0 {
+		form["org_id"] = strconv.FormatUint(uint64(opt.OrgID), 10)
+	}
+	if opt.ActivityID != 0 {
+		form["activity_id"] = strconv.FormatUint(uint64(opt.ActivityID), 10)
+	}
+	if opt.ProductID != 0 {
+		form["product_id"] = strconv.FormatUint(uint64(opt.ProductID), 10)
+	}
+	if opt.LeadID != "" {
+		form["lead_id"] = opt.LeadID
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, "/files", nil, body)
+	req, err := s.client.NewMultipartRequest(http.MethodPost, "/files", form, &MultipartFile{
+		FieldName:   "file",
+		FileName:    opt.FileName,
+		ContentType: opt.ContentType,
+		Reader: &progressReader{
+			ctx:      ctx,
+			reader:   r,
+			total:    opt.Size,
+			progress: opt.Progress,
+		},
+		Size: opt.Size,
+	})
 
 	if err != nil {
 		return nil, nil, err
 	}
 
+	stop := closeOnDone(ctx, r)
+	defer stop()
+
 	var record *FileResponse
 
 	resp, err := s.client.Do(ctx, req, &record)
@@ -181,6 +240,39 @@ func (s *FilesService) Upload(ctx context.Context, fileName string, filePath str
 	return record, resp, nil
 }
 
+// progressReader reports the bytes read from reader and stops reading once
+// ctx is done.
+type progressReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	read     int64
+	total    int64
+	progress func(sent int64, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+
+	if n > 0 && r.progress != nil {
+		r.progress(r.read, r.total)
+	}
+
+	return n, err
+}
+
+func (r *progressReader) Close() error {
+	if closer, ok := r.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
 // CreateRemoteLinkedFileOptions specifices the optional parameters to the
 // FilesService.CreateRemoteLinkedFile method.
 type CreateRemoteLinkedFileOptions struct {
@@ -284,3 +376,222 @@ func (s *FilesService) Delete(ctx context.Context, id int) (*Response, error) {
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// fileItemObjects lists the objects whose attached files can be listed.
+var fileItemObjects = map[EventObject]bool{
+	OBJECT_DEAL:         true,
+	OBJECT_PERSON:       true,
+	OBJECT_ORGANIZATION: true,
+	OBJECT_PRODUCT:      true,
+}
+
+// ListByItem lists files attached to a deal, person, organization or product.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Deals#getDealFiles
+func (s *FilesService) ListByItem(ctx context.Context, object EventObject, id int, opt *PaginationOptions) (*FilesResponse, *Response, error) {
+	if !fileItemObjects[object] {
+		return nil, nil, fmt.Errorf("files of %q cannot be listed", object)
+	}
+
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uri := fmt.Sprintf("%v/%v/files", path, id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *FilesResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// DownloadedFile describes a file fetched by FilesService.Download.
+type DownloadedFile struct {
+	File
+
+	// Size of the whole file in bytes.
+	Size int64
+
+	// Written is the number of bytes written by the download. It is lower
+	// than Size when a download was resumed.
+	Written int64
+
+	// Checksum is the hex encoded SHA-256 of the bytes written.
+	Checksum string
+
+	MimeType string
+}
+
+// Download streams the contents of a file into w.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Files/get_files_id_download
+func (s *FilesService) Download(ctx context.Context, id int, w io.Writer) (*DownloadedFile, *Response, error) {
+	return s.DownloadFrom(ctx, id, 0, w)
+}
+
+// DownloadFrom streams the contents of a file into w, starting at byte
+// offset. This resumes a download of which offset bytes were already fetched.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Files/get_files_id_download
+func (s *FilesService) DownloadFrom(ctx context.Context, id int, offset int64, w io.Writer) (*DownloadedFile, *Response, error) {
+	file, resp, err := s.GetByID(ctx, id)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	uri := fmt.Sprintf("/files/%v/download", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	hash := sha256.New()
+	dst := &offsetWriter{writer: io.MultiWriter(w, hash)}
+
+	resp, err = s.client.Do(ctx, req, dst)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	mimeType := file.Data.FileType
+
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		mimeType = mediaType
+	}
+
+	return &DownloadedFile{
+		File:     file.Data,
+		Size:     offset + dst.written,
+		Written:  dst.written,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+		MimeType: mimeType,
+	}, resp, nil
+}
+
+// offsetWriter counts the bytes written to writer.
+type offsetWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+
+	return n, err
+}
+
+// DownloadAll mirrors every file attached to a deal, person, organization or
+// product into dir. Each file is stored as "<id>-<file name>". Files already
+// present in full are skipped and partial files are resumed, so DownloadAll
+// can be run repeatedly. Files stored in remote locations such as Google
+// Drive cannot be downloaded and are skipped.
+func (s *FilesService) DownloadAll(ctx context.Context, object EventObject, id int, dir string) ([]DownloadedFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var downloaded []DownloadedFile
+
+	opt := &PaginationOptions{Limit: 100}
+
+	for {
+		record, _, err := s.ListByItem(ctx, object, id, opt)
+
+		if err != nil {
+			return downloaded, err
+		}
+
+		for _, file := range record.Data {
+			if file.RemoteLocation != "" && file.RemoteLocation != "s3" {
+				continue
+			}
+
+			result, err := s.mirror(ctx, file, dir)
+
+			if err != nil {
+				return downloaded, err
+			}
+
+			downloaded = append(downloaded, *result)
+		}
+
+		pagination := record.AdditionalData.Pagination
+
+		if !pagination.MoreItemsInCollection {
+			return downloaded, nil
+		}
+
+		opt.Start = pagination.NextStart
+	}
+}
+
+func (s *FilesService) mirror(ctx context.Context, file File, dir string) (*DownloadedFile, error) {
+	name := fmt.Sprintf("%d-%s", file.ID, filepath.Base(filepath.Clean("/"+file.FileName)))
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_RDWR|os.O_CREATE, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	// Hash the bytes already on disk so the checksum covers the whole file.
+	hash := sha256.New()
+	offset, err := io.Copy(hash, f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > int64(file.FileSize) {
+		if err := f.Truncate(0); err != nil {
+			return nil, err
+		}
+
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		hash.Reset()
+		offset = 0
+	}
+
+	if offset > 0 && offset == int64(file.FileSize) {
+		return &DownloadedFile{
+			File:     file,
+			Size:     offset,
+			Checksum: hex.EncodeToString(hash.Sum(nil)),
+			MimeType: file.FileType,
+		}, nil
+	}
+
+	result, _, err := s.DownloadFrom(ctx, file.ID, offset, io.MultiWriter(f, hash))
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	return result, nil
+}
@@ -0,0 +1,212 @@
+package pipedrive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilesService_UploadReader(t *testing.T) {
+	var form map[string]string
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/files" {
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+		}
+
+		reader, err := r.MultipartReader()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		form = map[string]string{}
+
+		for {
+			part, err := reader.NextPart()
+
+			if err == io.EOF {
+				break
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, _ := ioutil.ReadAll(part)
+			form[part.FormName()] = string(b)
+
+			if part.FormName() == "file" {
+				form["file_name"] = part.FileName()
+			}
+		}
+
+		w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+	}))
+
+	ctx := context.Background()
+	content := "hello, world"
+
+	var sent, total int64
+
+	file, _, err := client.Files.UploadReader(ctx, strings.NewReader(content), &FileUploadOptions{
+		FileName: "hello.txt",
+		Size:     int64(len(content)),
+		DealID:   5,
+		Progress: func(s, t int64) {
+			sent, total = s, t
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.Data.ID != 1 || form["file"] != content || form["file_name"] != "hello.txt" || form["deal_id"] != "5" {
+		t.Errorf("Got file %v and form %v", file, form)
+	}
+
+	if sent != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("Got progress %d/%d, want %d/%d", sent, total, len(content), len(content))
+	}
+
+	if _, _, err := client.Files.UploadReader(ctx, strings.NewReader(content), nil); err != nil {
+		t.Errorf("Got error %v uploading without options", err)
+	}
+
+	if form["file"] != content {
+		t.Errorf("Got form %v uploading without options", form)
+	}
+}
+
+func TestFilesService_UploadReaderCancel(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.Write([]byte("partial"))
+		cancel()
+	}()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		_, _, err := client.Files.UploadReader(ctx, reader, &FileUploadOptions{FileName: "stream.bin"})
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Got error %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Upload did not stop when canceled")
+	}
+
+	// The reader is closed once the upload is canceled.
+	if _, err := writer.Write([]byte("more")); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Got error %v writing to the upload, want io.ErrClosedPipe", err)
+	}
+}
+
+// newDownloadTestClient returns a client serving file 1 with content,
+// honoring Range headers if ranges is set.
+func newDownloadTestClient(t *testing.T, content string, ranges bool, requested *string) *Client {
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/files/1":
+			fmt.Fprintf(w, `{"success": true, "data": {"id": 1, "file_name": "notes.txt", "file_type": "txt", "file_size": %d}}`, len(content))
+		case "/v1/deals/7/files":
+			fmt.Fprintf(w, `{"success": true, "data": [{"id": 1, "file_name": "notes.txt", "file_type": "txt", "file_size": %d}]}`, len(content))
+		case "/v1/files/1/download":
+			*requested = r.Header.Get("Range")
+
+			var start int
+
+			if _, err := fmt.Sscanf(*requested, "bytes=%d-", &start); ranges && err == nil {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write([]byte(content[start:]))
+				return
+			}
+
+			w.Write([]byte(content))
+		default:
+			t.Errorf("Got unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFilesService_DownloadFrom(t *testing.T) {
+	content := "0123456789"
+
+	for _, ranges := range []bool{true, false} {
+		var requested string
+
+		client := newDownloadTestClient(t, content, ranges, &requested)
+
+		var b bytes.Buffer
+
+		file, _, err := client.Files.DownloadFrom(context.Background(), 1, 4, &b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A server answering 200 sends the whole file, whose prefix is
+		// skipped.
+		if b.String() != "456789" || requested != "bytes=4-" {
+			t.Errorf("Got %q with Range %q, ranges %v", b.String(), requested, ranges)
+		}
+
+		if file.Size != 10 || file.Written != 6 {
+			t.Errorf("Got file %v, ranges %v", file, ranges)
+		}
+	}
+}
+
+func TestFilesService_DownloadAll(t *testing.T) {
+	content := "0123456789"
+	dir := t.TempDir()
+
+	var requested string
+
+	client := newDownloadTestClient(t, content, true, &requested)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "1-notes.txt"), []byte("0123"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := client.Files.DownloadAll(context.Background(), OBJECT_DEAL, 7, dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := ioutil.ReadFile(filepath.Join(dir, "1-notes.txt"))
+	sum := sha256.Sum256([]byte(content))
+
+	if string(b) != content || requested != "bytes=4-" {
+		t.Errorf("Got %q with Range %q", b, requested)
+	}
+
+	if len(files) != 1 || files[0].Written != 6 || files[0].Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("Got files %v", files)
+	}
+}
@@ -0,0 +1,563 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// FilterOperator is the operator of a filter condition.
+type FilterOperator string
+
+const (
+	FilterOperatorEqual          FilterOperator = "="
+	FilterOperatorNotEqual       FilterOperator = "!="
+	FilterOperatorLess           FilterOperator = "<"
+	FilterOperatorGreater        FilterOperator = ">"
+	FilterOperatorLessOrEqual    FilterOperator = "<="
+	FilterOperatorGreaterOrEqual FilterOperator = ">="
+	FilterOperatorIsNull         FilterOperator = "IS NULL"
+	FilterOperatorIsNotNull      FilterOperator = "IS NOT NULL"
+	FilterOperatorStartsWith     FilterOperator = "LIKE '$%'"
+	FilterOperatorEndsWith       FilterOperator = "LIKE '%$'"
+	FilterOperatorContains       FilterOperator = "LIKE '%$%'"
+	FilterOperatorNotStartsWith  FilterOperator = "NOT LIKE '$%'"
+	FilterOperatorNotEndsWith    FilterOperator = "NOT LIKE '%$'"
+	FilterOperatorNotContains    FilterOperator = "NOT LIKE '%$%'"
+)
+
+// Filter condition glues.
+const (
+	FilterGlueAnd = "and"
+	FilterGlueOr  = "or"
+)
+
+// FilterDateMacro is a date relative to the day a filter is evaluated on.
+// Macros can be used as the value of conditions on date fields.
+type FilterDateMacro string
+
+const (
+	FilterDateToday       FilterDateMacro = "today"
+	FilterDateYesterday   FilterDateMacro = "yesterday"
+	FilterDateTomorrow    FilterDateMacro = "tomorrow"
+	FilterDateThisWeek    FilterDateMacro = "this_week"
+	FilterDateLastWeek    FilterDateMacro = "last_week"
+	FilterDateNextWeek    FilterDateMacro = "next_week"
+	FilterDateThisMonth   FilterDateMacro = "this_month"
+	FilterDateLastMonth   FilterDateMacro = "last_month"
+	FilterDateNextMonth   FilterDateMacro = "next_month"
+	FilterDateThisQuarter FilterDateMacro = "this_quarter"
+	FilterDateLastQuarter FilterDateMacro = "last_quarter"
+	FilterDateNextQuarter FilterDateMacro = "next_quarter"
+	FilterDateThisYear    FilterDateMacro = "this_year"
+	FilterDateLastYear    FilterDateMacro = "last_year"
+	FilterDateNextYear    FilterDateMacro = "next_year"
+)
+
+var filterDateMacros = map[FilterDateMacro]bool{
+	FilterDateToday:       true,
+	FilterDateYesterday:   true,
+	FilterDateTomorrow:    true,
+	FilterDateThisWeek:    true,
+	FilterDateLastWeek:    true,
+	FilterDateNextWeek:    true,
+	FilterDateThisMonth:   true,
+	FilterDateLastMonth:   true,
+	FilterDateNextMonth:   true,
+	FilterDateThisQuarter: true,
+	FilterDateLastQuarter: true,
+	FilterDateNextQuarter: true,
+	FilterDateThisYear:    true,
+	FilterDateLastYear:    true,
+	FilterDateNextYear:    true,
+}
+
+// filterTypeObjects maps filter types to the object they filter.
+var filterTypeObjects = map[string]EventObject{
+	"deals":    OBJECT_DEAL,
+	"people":   OBJECT_PERSON,
+	"org":      OBJECT_ORGANIZATION,
+	"products": OBJECT_PRODUCT,
+	"activity": OBJECT_ACTIVITY,
+}
+
+// FilterTypeObject returns the object filtered by filters of the given type,
+// as found in Filter.Type.
+func FilterTypeObject(filterType string) (EventObject, bool) {
+	object, ok := filterTypeObjects[filterType]
+
+	return object, ok
+}
+
+var (
+	comparisonOperators = []FilterOperator{
+		FilterOperatorEqual, FilterOperatorNotEqual,
+		FilterOperatorLess, FilterOperatorGreater,
+		FilterOperatorLessOrEqual, FilterOperatorGreaterOrEqual,
+		FilterOperatorIsNull, FilterOperatorIsNotNull,
+	}
+
+	textOperators = []FilterOperator{
+		FilterOperatorEqual, FilterOperatorNotEqual,
+		FilterOperatorIsNull, FilterOperatorIsNotNull,
+		FilterOperatorStartsWith, FilterOperatorEndsWith, FilterOperatorContains,
+		FilterOperatorNotStartsWith, FilterOperatorNotEndsWith, FilterOperatorNotContains,
+	}
+
+	equalityOperators = []FilterOperator{
+		FilterOperatorEqual, FilterOperatorNotEqual,
+		FilterOperatorIsNull, FilterOperatorIsNotNull,
+	}
+)
+
+// filterOperators lists the operators allowed in conditions on fields of
+// each type.
+var filterOperators = map[FieldType][]FilterOperator{
+	FieldTypeDouble:         comparisonOperators,
+	FieldTypeMonetary:       comparisonOperators,
+	FieldTypeInt:            comparisonOperators,
+	FieldTypeDate:           comparisonOperators,
+	FieldTypeDaterange:      comparisonOperators,
+	FieldTypeTime:           comparisonOperators,
+	FieldTypeTimerange:      comparisonOperators,
+	FieldTypeVarchar:        textOperators,
+	FieldTypeVarcharAuto:    textOperators,
+	FieldTypeVarcharOptions: textOperators,
+	FieldTypeText:           textOperators,
+	FieldTypePhone:          textOperators,
+	FieldTypeAddress:        textOperators,
+	FieldTypeEnum:           equalityOperators,
+	FieldTypeSet:            equalityOperators,
+	FieldTypeUser:           equalityOperators,
+	FieldTypeOrg:            equalityOperators,
+	FieldTypePeople:         equalityOperators,
+	FieldTypeStage:          equalityOperators,
+	FieldTypeStatus:         equalityOperators,
+}
+
+// FilterOperators returns the operators allowed in conditions on fields of
+// the given type.
+func FilterOperators(fieldType FieldType) []FilterOperator {
+	return append([]FilterOperator{}, filterOperators[fieldType]...)
+}
+
+// FilterCondition represents a single condition of a filter.
+//
+// Conditions created with Field refer to fields by Key. The FieldID is
+// resolved when the conditions are built with FilterBuilder.Build.
+type FilterCondition struct {
+	Object     EventObject    `json:"object"`
+	FieldID    string         `json:"field_id"`
+	Operator   FilterOperator `json:"operator"`
+	Value      string         `json:"value"`
+	ExtraValue interface{}    `json:"extra_value"`
+
+	Key string `json:"-"`
+}
+
+func (c FilterCondition) String() string {
+	return Stringify(c)
+}
+
+func (c FilterCondition) MarshalJSON() ([]byte, error) {
+	var value interface{}
+
+	if c.Operator != FilterOperatorIsNull && c.Operator != FilterOperatorIsNotNull {
+		value = c.Value
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"object":      c.Object,
+		"field_id":    c.FieldID,
+		"operator":    c.Operator,
+		"value":       value,
+		"extra_value": c.ExtraValue,
+	})
+}
+
+// UnmarshalJSON decodes a condition, accepting the field ID and value as
+// strings, numbers or null.
+func (c *FilterCondition) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Object     EventObject    `json:"object"`
+		FieldID    interface{}    `json:"field_id"`
+		Operator   FilterOperator `json:"operator"`
+		Value      interface{}    `json:"value"`
+		ExtraValue interface{}    `json:"extra_value"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*c = FilterCondition{
+		Object:     raw.Object,
+		FieldID:    optionID(raw.FieldID),
+		Operator:   raw.Operator,
+		Value:      optionID(raw.Value),
+		ExtraValue: raw.ExtraValue,
+	}
+
+	return nil
+}
+
+// filterConditions implements the FilterTerm interface.
+func (c FilterCondition) filterConditions() []FilterCondition {
+	return []FilterCondition{c}
+}
+
+// FilterConditionGroup represents a group of filter conditions joined by Glue.
+type FilterConditionGroup struct {
+	Glue       string            `json:"glue"`
+	Conditions []FilterCondition `json:"conditions"`
+}
+
+// FilterTerm is one or more filter conditions, as returned by the methods
+// of FilterField.
+type FilterTerm interface {
+	filterConditions() []FilterCondition
+}
+
+// filterRange is the pair of conditions making up a range.
+type filterRange [2]FilterCondition
+
+func (r filterRange) filterConditions() []FilterCondition {
+	return r[:]
+}
+
+// FilterField refers to a field in filter conditions.
+type FilterField struct {
+	object EventObject
+	key    string
+}
+
+// Field refers to the field with the given key of the object filtered.
+func Field(key string) FilterField {
+	return FilterField{key: key}
+}
+
+// FieldOf refers to the field with the given key of another object, such as
+// the person fields in a deal filter.
+func FieldOf(object EventObject, key string) FilterField {
+	return FilterField{object: object, key: key}
+}
+
+func (f FilterField) condition(operator FilterOperator, value interface{}) FilterCondition {
+	return FilterCondition{
+		Object:   f.object,
+		Key:      f.key,
+		Operator: operator,
+		Value:    filterValue(value),
+	}
+}
+
+// Eq matches records where the field equals value.
+func (f FilterField) Eq(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorEqual, value)
+}
+
+// NotEq matches records where the field does not equal value.
+func (f FilterField) NotEq(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorNotEqual, value)
+}
+
+// Gt matches records where the field is greater than value.
+func (f FilterField) Gt(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorGreater, value)
+}
+
+// Gte matches records where the field is greater than or equal to value.
+func (f FilterField) Gte(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorGreaterOrEqual, value)
+}
+
+// Lt matches records where the field is less than value.
+func (f FilterField) Lt(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorLess, value)
+}
+
+// Lte matches records where the field is less than or equal to value.
+func (f FilterField) Lte(value interface{}) FilterCondition {
+	return f.condition(FilterOperatorLessOrEqual, value)
+}
+
+// Between matches records where the field lies between from and to, both
+// included. The range is made of two conditions, it can only be used with
+// FilterBuilder.And.
+func (f FilterField) Between(from, to interface{}) FilterTerm {
+	return filterRange{
+		f.condition(FilterOperatorGreaterOrEqual, from),
+		f.condition(FilterOperatorLessOrEqual, to),
+	}
+}
+
+// IsEmpty matches records where the field has no value.
+func (f FilterField) IsEmpty() FilterCondition {
+	return f.condition(FilterOperatorIsNull, nil)
+}
+
+// IsNotEmpty matches records where the field has a value.
+func (f FilterField) IsNotEmpty() FilterCondition {
+	return f.condition(FilterOperatorIsNotNull, nil)
+}
+
+// Contains matches records where the field contains value.
+func (f FilterField) Contains(value string) FilterCondition {
+	return f.condition(FilterOperatorContains, value)
+}
+
+// NotContains matches records where the field does not contain value.
+func (f FilterField) NotContains(value string) FilterCondition {
+	return f.condition(FilterOperatorNotContains, value)
+}
+
+// StartsWith matches records where the field starts with value.
+func (f FilterField) StartsWith(value string) FilterCondition {
+	return f.condition(FilterOperatorStartsWith, value)
+}
+
+// EndsWith matches records where the field ends with value.
+func (f FilterField) EndsWith(value string) FilterCondition {
+	return f.condition(FilterOperatorEndsWith, value)
+}
+
+// filterValue formats value as it is sent in filter conditions.
+func filterValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case FilterDateMacro:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02")
+	case Date:
+		return v.Format("2006-01-02")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FilterBuilder builds filter conditions.
+//
+// Pipedrive filters hold two groups of conditions: records match when all
+// the conditions of the first group and, if not empty, any condition of the
+// second group match.
+type FilterBuilder struct {
+	object EventObject
+	and    []FilterCondition
+	or     []FilterCondition
+	err    error
+}
+
+// NewFilterBuilder returns a builder of conditions filtering object.
+func NewFilterBuilder(object EventObject) *FilterBuilder {
+	return &FilterBuilder{object: object}
+}
+
+// ParseFilterConditions returns a builder holding existing conditions of a
+// filter of object, such as those of a FilterResponse. The conditions can
+// be modified and built again.
+func ParseFilterConditions(object EventObject, conditions FilterConditions) (*FilterBuilder, error) {
+	b := NewFilterBuilder(object)
+
+	if conditions.Glue != "" && conditions.Glue != FilterGlueAnd {
+		return nil, fmt.Errorf("unsupported filter glue %q", conditions.Glue)
+	}
+
+	for _, group := range conditions.Conditions {
+		switch group.Glue {
+		case FilterGlueAnd:
+			b.and = append(b.and, group.Conditions...)
+		case FilterGlueOr:
+			b.or = append(b.or, group.Conditions...)
+		default:
+			return nil, fmt.Errorf("unsupported filter glue %q", group.Glue)
+		}
+	}
+
+	return b, nil
+}
+
+// Object returns the object filtered.
+func (b *FilterBuilder) Object() EventObject {
+	return b.object
+}
+
+// And adds terms which all have to match.
+func (b *FilterBuilder) And(terms ...FilterTerm) *FilterBuilder {
+	for _, term := range terms {
+		b.and = append(b.and, term.filterConditions()...)
+	}
+
+	return b
+}
+
+// Or adds terms of which at least one has to match.
+func (b *FilterBuilder) Or(terms ...FilterTerm) *FilterBuilder {
+	for _, term := range terms {
+		conditions := term.filterConditions()
+
+		if len(conditions) > 1 && b.err == nil {
+			b.err = fmt.Errorf("range on field %q cannot be used in or conditions", conditions[0].Key)
+		}
+
+		b.or = append(b.or, conditions...)
+	}
+
+	return b
+}
+
+// Conditions returns the conditions as they are, without resolving or
+// validating them.
+func (b *FilterBuilder) Conditions() FilterConditions {
+	return FilterConditions{
+		Glue: FilterGlueAnd,
+		Conditions: []FilterConditionGroup{
+			{Glue: FilterGlueAnd, Conditions: append([]FilterCondition{}, b.and...)},
+			{Glue: FilterGlueOr, Conditions: append([]FilterCondition{}, b.or...)},
+		},
+	}
+}
+
+// Build resolves the fields of the conditions using metadata, validates
+// the conditions and returns them. A FilterConditionError is returned for
+// the first invalid condition.
+func (b *FilterBuilder) Build(metadata FieldMetadata) (FilterConditions, error) {
+	if b.err != nil {
+		return FilterConditions{}, b.err
+	}
+
+	conditions := b.Conditions()
+
+	for _, group := range conditions.Conditions {
+		for i := range group.Conditions {
+			if err := b.resolve(&group.Conditions[i], metadata); err != nil {
+				return FilterConditions{}, err
+			}
+		}
+	}
+
+	return conditions, nil
+}
+
+// Validate reports whether the conditions are valid according to metadata.
+func (b *FilterBuilder) Validate(metadata FieldMetadata) error {
+	_, err := b.Build(metadata)
+
+	return err
+}
+
+func (b *FilterBuilder) resolve(c *FilterCondition, metadata FieldMetadata) error {
+	if c.Object == "" {
+		c.Object = b.object
+	}
+
+	var (
+		field FieldDefinition
+		ok    bool
+	)
+
+	if c.Key != "" {
+		field, ok = metadata.ByKey(c.Object, c.Key)
+	} else if id, err := strconv.Atoi(c.FieldID); err == nil {
+		field, ok = metadata.ByID(c.Object, id)
+	}
+
+	if !ok {
+		return &FilterConditionError{Condition: *c, Message: "unknown field"}
+	}
+
+	c.Key = field.Key
+	c.FieldID = strconv.Itoa(field.ID)
+
+	return validateFilterCondition(c, field)
+}
+
+var filterTimeRegexp = regexp.MustCompile(`^\d{2}:\d{2}(:\d{2})?$`)
+
+// validateFilterCondition checks the operator and value of c against the
+// type of field. Option labels of enum and set fields are replaced by the
+// option ID.
+func validateFilterCondition(c *FilterCondition, field FieldDefinition) error {
+	operators, ok := filterOperators[field.FieldType]
+
+	if !ok {
+		return &FilterConditionError{Condition: *c, Message: fmt.Sprintf("fields of type %q cannot be filtered", field.FieldType)}
+	}
+
+	allowed := false
+
+	for _, operator := range operators {
+		if operator == c.Operator {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return &FilterConditionError{Condition: *c, Message: fmt.Sprintf("operator %q is not allowed on fields of type %q", c.Operator, field.FieldType)}
+	}
+
+	if c.Operator == FilterOperatorIsNull || c.Operator == FilterOperatorIsNotNull {
+		c.Value = ""
+		return nil
+	}
+
+	invalid := func(kind string) error {
+		return &FilterConditionError{Condition: *c, Message: fmt.Sprintf("value %q is not %s", c.Value, kind)}
+	}
+
+	switch field.FieldType {
+	case FieldTypeDouble, FieldTypeMonetary, FieldTypeInt:
+		if _, err := strconv.ParseFloat(c.Value, 64); err != nil {
+			return invalid("a number")
+		}
+
+	case FieldTypeDate, FieldTypeDaterange:
+		if filterDateMacros[FilterDateMacro(c.Value)] {
+			break
+		}
+
+		if _, err := time.Parse("2006-01-02", c.Value); err != nil {
+			return invalid("a date")
+		}
+
+	case FieldTypeTime, FieldTypeTimerange:
+		if !filterTimeRegexp.MatchString(c.Value) {
+			return invalid("a time")
+		}
+
+	case FieldTypeEnum, FieldTypeSet:
+		if _, ok := field.Option(c.Value); ok {
+			break
+		}
+
+		option, ok := field.OptionByLabel(c.Value)
+
+		if !ok {
+			return invalid("an option of the field")
+		}
+
+		c.Value = optionID(option.ID)
+
+	case FieldTypeUser, FieldTypeOrg, FieldTypePeople, FieldTypeStage:
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return invalid("an ID")
+		}
+	}
+
+	return nil
+}
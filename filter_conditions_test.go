@@ -0,0 +1,146 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var filterTestMetadata = FieldMetadata{
+	OBJECT_DEAL: {
+		{ID: 1, Key: "title", Name: "Title", FieldType: FieldTypeVarchar},
+		{ID: 2, Key: "value", Name: "Value", FieldType: FieldTypeMonetary},
+		{ID: 3, Key: "status", Name: "Status", FieldType: FieldTypeStatus},
+		{ID: 4, Key: "tier", Name: "Tier", FieldType: FieldTypeEnum, Options: []Option{{ID: 7.0, Label: "Gold"}}},
+		{ID: 5, Key: "close_time", Name: "Close", FieldType: FieldTypeDate},
+	},
+	OBJECT_PERSON: {
+		{ID: 9, Key: "name", Name: "Name", FieldType: FieldTypeVarchar},
+	},
+}
+
+func TestFilterBuilder_Build(t *testing.T) {
+	b := NewFilterBuilder(OBJECT_DEAL).
+		And(
+			Field("value").Between(100, 200.5),
+			Field("tier").Eq("Gold"),
+			FieldOf(OBJECT_PERSON, "name").StartsWith("A"),
+		).
+		Or(
+			Field("status").Eq("won"),
+			Field("close_time").IsEmpty(),
+		)
+
+	conditions, err := b.Build(filterTestMetadata)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := FilterConditions{
+		Glue: FilterGlueAnd,
+		Conditions: []FilterConditionGroup{
+			{Glue: FilterGlueAnd, Conditions: []FilterCondition{
+				{Object: OBJECT_DEAL, FieldID: "2", Key: "value", Operator: FilterOperatorGreaterOrEqual, Value: "100"},
+				{Object: OBJECT_DEAL, FieldID: "2", Key: "value", Operator: FilterOperatorLessOrEqual, Value: "200.5"},
+				{Object: OBJECT_DEAL, FieldID: "4", Key: "tier", Operator: FilterOperatorEqual, Value: "7"},
+				{Object: OBJECT_PERSON, FieldID: "9", Key: "name", Operator: FilterOperatorStartsWith, Value: "A"},
+			}},
+			{Glue: FilterGlueOr, Conditions: []FilterCondition{
+				{Object: OBJECT_DEAL, FieldID: "3", Key: "status", Operator: FilterOperatorEqual, Value: "won"},
+				{Object: OBJECT_DEAL, FieldID: "5", Key: "close_time", Operator: FilterOperatorIsNull},
+			}},
+		},
+	}
+
+	if !reflect.DeepEqual(conditions, want) {
+		t.Errorf("Got conditions %v, want %v", conditions, want)
+	}
+
+	// Building leaves the conditions of the builder unresolved.
+	if c := b.Conditions().Conditions[0].Conditions[0]; c.FieldID != "" || c.Object != "" {
+		t.Errorf("Got condition %v after building", c)
+	}
+
+	b, err = ParseFilterConditions(OBJECT_DEAL, conditions)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rebuilt, err := b.Build(filterTestMetadata); err != nil || !reflect.DeepEqual(rebuilt, want) {
+		t.Errorf("Got conditions %v and error %v rebuilding parsed conditions", rebuilt, err)
+	}
+}
+
+func TestFilterBuilder_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		term FilterTerm
+		want string
+	}{
+		{"unknown field", Field("missing").Eq(1), "unknown field"},
+		{"operator", Field("tier").Contains("Go"), `operator "LIKE '%$%'" is not allowed on fields of type "enum"`},
+		{"number", Field("value").Gt("lots"), `value "lots" is not a number`},
+		{"date", Field("close_time").Lt("soon"), `value "soon" is not a date`},
+		{"option", Field("tier").Eq("Silver"), `value "Silver" is not an option of the field`},
+	}
+
+	for _, tt := range tests {
+		err := NewFilterBuilder(OBJECT_DEAL).And(tt.term).Validate(filterTestMetadata)
+
+		var conditionError *FilterConditionError
+
+		if !errors.As(err, &conditionError) || conditionError.Message != tt.want {
+			t.Errorf("%s: got error %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	if err := NewFilterBuilder(OBJECT_DEAL).And(Field("close_time").Gte(FilterDateThisMonth)).Validate(filterTestMetadata); err != nil {
+		t.Errorf("Got error %v for a date macro", err)
+	}
+
+	if err := NewFilterBuilder(OBJECT_DEAL).Or(Field("value").Between(1, 2)).Validate(filterTestMetadata); err == nil {
+		t.Error("Got no error for a range in or conditions")
+	}
+}
+
+func TestParseFilterConditions(t *testing.T) {
+	var conditions FilterConditions
+
+	err := json.Unmarshal([]byte(`{"glue": "and", "conditions": [
+		{"glue": "and", "conditions": [{"object": "deal", "field_id": 2, "operator": ">", "value": 10, "extra_value": null}]},
+		{"glue": "or", "conditions": [{"object": "deal", "field_id": "3", "operator": "=", "value": "won", "extra_value": null}]}
+	]}`), &conditions)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ParseFilterConditions(OBJECT_DEAL, conditions)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	built, err := b.Build(filterTestMetadata)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c := built.Conditions[0].Conditions[0]; c.Key != "value" || c.FieldID != "2" || c.Value != "10" {
+		t.Errorf("Got condition %v", c)
+	}
+
+	if c := built.Conditions[1].Conditions[0]; c.Key != "status" || c.Value != "won" {
+		t.Errorf("Got condition %v", c)
+	}
+
+	conditions.Conditions[1].Glue = "xor"
+
+	if _, err := ParseFilterConditions(OBJECT_DEAL, conditions); err == nil {
+		t.Error("Got no error for an unsupported glue")
+	}
+}
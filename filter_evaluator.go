@@ -0,0 +1,417 @@
+package pipedrive
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterEvaluator decides locally whether records match the conditions of
+// a filter, without querying the Pipedrive API.
+//
+// Conditions are evaluated the way Pipedrive does: a record matches when
+// all conditions of the "and" group match and, unless it is empty, at least
+// one condition of the "or" group matches. As in SQL, an empty field only
+// matches the IS NULL operator. Text comparisons ignore case.
+type FilterEvaluator struct {
+	object     EventObject
+	conditions FilterConditions
+	fields     map[EventObject]map[string]FieldDefinition
+
+	// Now returns the current time. Date macros such as "last_quarter" are
+	// relative to it. Defaults to time.Now.
+	Now func() time.Time
+
+	// Location is the time zone days start in, used to convert timestamps
+	// such as add_time into dates. Defaults to UTC.
+	Location *time.Location
+}
+
+// NewFilterEvaluator returns an evaluator of conditions filtering object,
+// such as those of a FilterResponse. Field metadata is needed for all
+// objects the conditions refer to.
+func NewFilterEvaluator(object EventObject, conditions FilterConditions, metadata FieldMetadata) (*FilterEvaluator, error) {
+	builder, err := ParseFilterConditions(object, conditions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := builder.Build(metadata)
+
+	if err != nil {
+		return nil, err
+	}
+
+	e := &FilterEvaluator{
+		object:     object,
+		conditions: resolved,
+		fields:     map[EventObject]map[string]FieldDefinition{},
+		Now:        time.Now,
+		Location:   time.UTC,
+	}
+
+	for object, fields := range metadata {
+		e.fields[object] = map[string]FieldDefinition{}
+
+		for _, field := range fields {
+			e.fields[object][strconv.Itoa(field.ID)] = field
+		}
+	}
+
+	return e, nil
+}
+
+// Match reports whether record matches the filter. The record is a Deal,
+// Person, Organization or Activity, or a pointer to one. Conditions on
+// other objects, such as person fields in a deal filter, are evaluated
+// against the related records given.
+func (e *FilterEvaluator) Match(record interface{}, related ...interface{}) (bool, error) {
+	records := map[EventObject]map[string]interface{}{}
+
+	for _, r := range append([]interface{}{record}, related...) {
+		object, ok := recordObject(r)
+
+		if !ok {
+			return false, fmt.Errorf("unsupported record type %T", r)
+		}
+
+		values, err := recordValues(r)
+
+		if err != nil {
+			return false, err
+		}
+
+		records[object] = values
+	}
+
+	if _, ok := records[e.object]; !ok {
+		return false, fmt.Errorf("cannot evaluate a %v filter on %T", e.object, record)
+	}
+
+	matched := true
+
+	for _, group := range e.conditions.Conditions {
+		ok, err := e.matchGroup(group, records)
+
+		if err != nil {
+			return false, err
+		}
+
+		matched = matched && ok
+	}
+
+	return matched, nil
+}
+
+func (e *FilterEvaluator) matchGroup(group FilterConditionGroup, records map[EventObject]map[string]interface{}) (bool, error) {
+	if len(group.Conditions) == 0 {
+		return true, nil
+	}
+
+	for _, c := range group.Conditions {
+		values, ok := records[c.Object]
+
+		if !ok {
+			return false, &FilterConditionError{Condition: c, Message: "no " + string(c.Object) + " record given"}
+		}
+
+		ok, err := e.matchCondition(c, e.fields[c.Object][c.FieldID], values[c.Key])
+
+		if err != nil {
+			return false, err
+		}
+
+		if group.Glue == FilterGlueOr && ok {
+			return true, nil
+		}
+
+		if group.Glue == FilterGlueAnd && !ok {
+			return false, nil
+		}
+	}
+
+	return group.Glue == FilterGlueAnd, nil
+}
+
+func (e *FilterEvaluator) matchCondition(c FilterCondition, field FieldDefinition, value interface{}) (bool, error) {
+	values := flattenFilterValue(value, field.FieldType)
+
+	switch c.Operator {
+	case FilterOperatorIsNull:
+		return len(values) == 0, nil
+	case FilterOperatorIsNotNull:
+		return len(values) > 0, nil
+	}
+
+	if len(values) == 0 {
+		return false, nil
+	}
+
+	negated := c.Operator == FilterOperatorNotEqual ||
+		c.Operator == FilterOperatorNotContains ||
+		c.Operator == FilterOperatorNotStartsWith ||
+		c.Operator == FilterOperatorNotEndsWith
+
+	// Fields holding several values, such as sets, emails or phones, match
+	// when any value matches, or when no value matches negated operators.
+	for _, v := range values {
+		ok, err := e.compare(c, field, v)
+
+		if err != nil {
+			return false, err
+		}
+
+		if negated && !ok {
+			return false, nil
+		}
+
+		if !negated && ok {
+			return true, nil
+		}
+	}
+
+	return negated, nil
+}
+
+func (e *FilterEvaluator) compare(c FilterCondition, field FieldDefinition, value string) (bool, error) {
+	switch field.FieldType {
+	case FieldTypeDouble, FieldTypeMonetary, FieldTypeInt:
+		have, err := strconv.ParseFloat(value, 64)
+
+		if err != nil {
+			return false, nil
+		}
+
+		want, err := strconv.ParseFloat(c.Value, 64)
+
+		if err != nil {
+			return false, &FilterConditionError{Condition: c, Message: "value is not a number"}
+		}
+
+		cmp := compareFloats(have, want)
+
+		return compareOrdered(c.Operator, cmp, cmp), nil
+
+	case FieldTypeDate, FieldTypeDaterange:
+		have, ok := e.parseDate(value)
+
+		if !ok {
+			return false, nil
+		}
+
+		from, to, err := e.dateRange(c.Value)
+
+		if err != nil {
+			return false, &FilterConditionError{Condition: c, Message: err.Error()}
+		}
+
+		day := float64(have.Unix())
+
+		return compareOrdered(c.Operator, compareFloats(day, float64(from.Unix())), compareFloats(day, float64(to.Unix()))), nil
+
+	case FieldTypeTime, FieldTypeTimerange:
+		cmp := strings.Compare(normalizeTime(value), normalizeTime(c.Value))
+
+		return compareOrdered(c.Operator, cmp, cmp), nil
+
+	case FieldTypeEnum, FieldTypeSet, FieldTypeUser, FieldTypeOrg, FieldTypePeople, FieldTypeStage:
+		cmp := strings.Compare(value, c.Value)
+
+		return compareOrdered(c.Operator, cmp, cmp), nil
+	}
+
+	have, want := strings.ToLower(value), strings.ToLower(c.Value)
+
+	switch c.Operator {
+	case FilterOperatorEqual, FilterOperatorNotEqual:
+		return (have == want) == (c.Operator == FilterOperatorEqual), nil
+	case FilterOperatorContains, FilterOperatorNotContains:
+		return strings.Contains(have, want) == (c.Operator == FilterOperatorContains), nil
+	case FilterOperatorStartsWith, FilterOperatorNotStartsWith:
+		return strings.HasPrefix(have, want) == (c.Operator == FilterOperatorStartsWith), nil
+	case FilterOperatorEndsWith, FilterOperatorNotEndsWith:
+		return strings.HasSuffix(have, want) == (c.Operator == FilterOperatorEndsWith), nil
+	}
+
+	cmp := strings.Compare(have, want)
+
+	return compareOrdered(c.Operator, cmp, cmp), nil
+}
+
+// compareOrdered compares a value to the range [from, to] with operator,
+// given the results of comparing it to from and to: -1 when lower, 0 when
+// equal and 1 when greater. A single value is its own range.
+func compareOrdered(operator FilterOperator, fromCmp, toCmp int) bool {
+	switch operator {
+	case FilterOperatorEqual:
+		return fromCmp >= 0 && toCmp <= 0
+	case FilterOperatorNotEqual:
+		return fromCmp < 0 || toCmp > 0
+	case FilterOperatorLess:
+		return fromCmp < 0
+	case FilterOperatorLessOrEqual:
+		return toCmp <= 0
+	case FilterOperatorGreater:
+		return toCmp > 0
+	case FilterOperatorGreaterOrEqual:
+		return fromCmp >= 0
+	}
+
+	return false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// parseDate returns the day of a date or timestamp value.
+func (e *FilterEvaluator) parseDate(value string) (time.Time, bool) {
+	if t, err := time.ParseInLocation(DateTimeLayout, value, time.UTC); err == nil {
+		t = t.In(e.location())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+
+	if len(value) >= 10 {
+		if t, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dateRange returns the first and last day denoted by a date or date macro.
+func (e *FilterEvaluator) dateRange(value string) (time.Time, time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, t, nil
+	}
+
+	now := time.Now()
+
+	if e.Now != nil {
+		now = e.Now()
+	}
+
+	now = now.In(e.location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	weekStart := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	quarterStart := time.Date(today.Year(), today.Month()-(today.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	yearStart := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+
+	period := func(start time.Time, years, months, days, offset int) (time.Time, time.Time, error) {
+		start = start.AddDate(years*offset, months*offset, days*offset)
+		return start, start.AddDate(years, months, days-1), nil
+	}
+
+	switch FilterDateMacro(value) {
+	case FilterDateToday:
+		return period(today, 0, 0, 1, 0)
+	case FilterDateYesterday:
+		return period(today, 0, 0, 1, -1)
+	case FilterDateTomorrow:
+		return period(today, 0, 0, 1, 1)
+	case FilterDateThisWeek:
+		return period(weekStart, 0, 0, 7, 0)
+	case FilterDateLastWeek:
+		return period(weekStart, 0, 0, 7, -1)
+	case FilterDateNextWeek:
+		return period(weekStart, 0, 0, 7, 1)
+	case FilterDateThisMonth:
+		return period(monthStart, 0, 1, 0, 0)
+	case FilterDateLastMonth:
+		return period(monthStart, 0, 1, 0, -1)
+	case FilterDateNextMonth:
+		return period(monthStart, 0, 1, 0, 1)
+	case FilterDateThisQuarter:
+		return period(quarterStart, 0, 3, 0, 0)
+	case FilterDateLastQuarter:
+		return period(quarterStart, 0, 3, 0, -1)
+	case FilterDateNextQuarter:
+		return period(quarterStart, 0, 3, 0, 1)
+	case FilterDateThisYear:
+		return period(yearStart, 1, 0, 0, 0)
+	case FilterDateLastYear:
+		return period(yearStart, 1, 0, 0, -1)
+	case FilterDateNextYear:
+		return period(yearStart, 1, 0, 0, 1)
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("value %q is not a date", value)
+}
+
+func (e *FilterEvaluator) location() *time.Location {
+	if e.Location == nil {
+		return time.UTC
+	}
+
+	return e.Location
+}
+
+// normalizeTime pads times to the HH:MM:SS form so they compare as strings.
+func normalizeTime(value string) string {
+	if len(value) == 5 {
+		return value + ":00"
+	}
+
+	return value
+}
+
+// flattenFilterValue turns a record field value into the list of string
+// values conditions are compared to. Empty values yield an empty list.
+func flattenFilterValue(value interface{}, fieldType FieldType) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+
+	case string:
+		if v == "" {
+			return nil
+		}
+
+		if fieldType == FieldTypeSet {
+			return strings.Split(v, ",")
+		}
+
+		return []string{v}
+
+	case bool:
+		if v {
+			return []string{"1"}
+		}
+		return []string{"0"}
+
+	case []interface{}:
+		var values []string
+
+		for _, item := range v {
+			values = append(values, flattenFilterValue(item, fieldType)...)
+		}
+
+		return values
+
+	case map[string]interface{}:
+		// References to other records, such as org_id, and the items of
+		// email and phone lists hold their value under "value".
+		if inner, ok := v["value"]; ok {
+			return flattenFilterValue(inner, fieldType)
+		}
+
+		if inner, ok := v["id"]; ok {
+			return flattenFilterValue(inner, fieldType)
+		}
+
+		return nil
+	}
+
+	return []string{optionID(value)}
+}
@@ -0,0 +1,117 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type filterFixture struct {
+	Object EventObject         `json:"object"`
+	Now    time.Time           `json:"now"`
+	Fields []FieldDefinition   `json:"fields"`
+	Filter FilterResponse      `json:"filter"`
+	Cases  []filterFixtureCase `json:"cases"`
+}
+
+type filterFixtureCase struct {
+	Name   string          `json:"name"`
+	Match  bool            `json:"match"`
+	Record json.RawMessage `json:"record"`
+}
+
+func TestFilterEvaluator_Match(t *testing.T) {
+	files, err := filepath.Glob("testdata/filters/*.json")
+
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Could not find filter fixtures: %v", err)
+	}
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fixture filterFixture
+
+		if err := json.Unmarshal(b, &fixture); err != nil {
+			t.Fatalf("%v: %v", file, err)
+		}
+
+		evaluator, err := NewFilterEvaluator(fixture.Object, fixture.Filter.Data.Conditions, FieldMetadata{
+			fixture.Object: fixture.Fields,
+		})
+
+		if err != nil {
+			t.Fatalf("%v: could not create evaluator: %v", file, err)
+		}
+
+		evaluator.Now = func() time.Time { return fixture.Now }
+
+		for _, c := range fixture.Cases {
+			var record interface{}
+
+			switch fixture.Object {
+			case OBJECT_DEAL:
+				record = &Deal{}
+			case OBJECT_PERSON:
+				record = &Person{}
+			case OBJECT_ORGANIZATION:
+				record = &Organization{}
+			case OBJECT_ACTIVITY:
+				record = &Activity{}
+			}
+
+			if err := json.Unmarshal(c.Record, record); err != nil {
+				t.Fatalf("%v: %v: %v", file, c.Name, err)
+			}
+
+			match, err := evaluator.Match(record)
+
+			if err != nil {
+				t.Errorf("%v: %v: %v", file, c.Name, err)
+			} else if match != c.Match {
+				t.Errorf("%v: %v: got match %v, want %v", file, c.Name, match, c.Match)
+			}
+		}
+	}
+}
+
+func TestFilterEvaluator_RelatedRecords(t *testing.T) {
+	conditions, err := NewFilterBuilder(OBJECT_DEAL).
+		And(Field("title").Contains("renewal")).
+		And(FieldOf(OBJECT_PERSON, "name").Eq("Ada")).
+		Build(FieldMetadata{
+			OBJECT_DEAL:   {{ID: 1, Key: "title", FieldType: FieldTypeVarchar}},
+			OBJECT_PERSON: {{ID: 2, Key: "name", FieldType: FieldTypeVarchar}},
+		})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evaluator, err := NewFilterEvaluator(OBJECT_DEAL, conditions, FieldMetadata{
+		OBJECT_DEAL:   {{ID: 1, Key: "title", FieldType: FieldTypeVarchar}},
+		OBJECT_PERSON: {{ID: 2, Key: "name", FieldType: FieldTypeVarchar}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deal := Deal{Title: "2026 Renewal"}
+
+	if _, err := evaluator.Match(deal); err == nil {
+		t.Error("Expected an error without the related person")
+	}
+
+	match, err := evaluator.Match(deal, Person{Name: "ada"})
+
+	if err != nil || !match {
+		t.Errorf("Expected deal to match, got %v, %v", match, err)
+	}
+}
@@ -30,19 +30,11 @@ func (f Filter) String() string {
 	return Stringify(f)
 }
 
-// FilterConditions represents filter conditions.
+// FilterConditions represents filter conditions. Use a FilterBuilder to
+// build or inspect them.
 type FilterConditions struct {
-	Glue       string `json:"glue"`
-	Conditions []struct {
-		Glue       string `json:"glue"`
-		Conditions []struct {
-			Object     string      `json:"object"`
-			FieldID    string      `json:"field_id"`
-			Operator   string      `json:"operator"`
-			Value      string      `json:"value"`
-			ExtraValue interface{} `json:"extra_value"`
-		} `json:"conditions"`
-	} `json:"conditions"`
+	Glue       string                 `json:"glue"`
+	Conditions []FilterConditionGroup `json:"conditions"`
 }
 
 // FilterResponse represents single filter response.
@@ -111,11 +103,12 @@ func (s *FiltersService) GetByID(ctx context.Context, id int) (*FilterResponse,
 }
 
 // FilterCreateOptions specifices the optional parameters to the
-// FiltersService.Create method.
+// FiltersService.Create method. Conditions can be built with a
+// FilterBuilder.
 type FilterCreateOptions struct {
-	Name       string `url:"name,omitempty"`
-	Conditions string `url:"conditions,omitempty"`
-	Type       string `url:"type,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	Conditions FilterConditions `json:"conditions"`
+	Type       string           `json:"type,omitempty"`
 }
 
 // Create a filter.
@@ -142,8 +135,8 @@ func (s *FiltersService) Create(ctx context.Context, opt *FilterCreateOptions) (
 // FilterUpdateOptions specifices the optional parameters to the
 // FiltersService.Update method.
 type FilterUpdateOptions struct {
-	Name       string `url:"name,omitempty"`
-	Conditions string `url:"conditions,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Conditions *FilterConditions `json:"conditions,omitempty"`
 }
 
 // Update a specific filter.
@@ -5,4 +5,5 @@ go 1.17
 require (
 	github.com/go-test/deep v1.0.8
 	github.com/google/go-querystring v1.1.0
+	gopkg.in/yaml.v3 v3.0.1
 )
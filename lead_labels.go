@@ -0,0 +1,140 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// LeadLabelsService handles lead labels related
+// methods of the Pipedrive API.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/LeadLabels
+type LeadLabelsService service
+
+// Lead label colors.
+const (
+	LeadLabelColorGreen  = "green"
+	LeadLabelColorBlue   = "blue"
+	LeadLabelColorRed    = "red"
+	LeadLabelColorYellow = "yellow"
+	LeadLabelColorPurple = "purple"
+	LeadLabelColorGray   = "gray"
+)
+
+// LeadLabel represents a Pipedrive lead label.
+type LeadLabel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	AddTime    string `json:"add_time"`
+	UpdateTime string `json:"update_time"`
+}
+
+func (l LeadLabel) String() string {
+	return Stringify(l)
+}
+
+// LeadLabelsResponse represents multiple lead labels response.
+type LeadLabelsResponse struct {
+	Success bool        `json:"success"`
+	Data    []LeadLabel `json:"data"`
+}
+
+// LeadLabelResponse represents single lead label response.
+type LeadLabelResponse struct {
+	Success bool      `json:"success"`
+	Data    LeadLabel `json:"data"`
+}
+
+// List all lead labels.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/LeadLabels#getLeadLabels
+func (s *LeadLabelsService) List(ctx context.Context) (*LeadLabelsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/leadLabels", nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadLabelsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// LeadLabelCreateOptions specifices the optional parameters to the
+// LeadLabelsService.Create method.
+type LeadLabelCreateOptions struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Create a lead label.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/LeadLabels#addLeadLabel
+func (s *LeadLabelsService) Create(ctx context.Context, opt *LeadLabelCreateOptions) (*LeadLabelResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, "/leadLabels", nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadLabelResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// LeadLabelUpdateOptions specifices the optional parameters to the
+// LeadLabelsService.Update method.
+type LeadLabelUpdateOptions struct {
+	Name  string `json:"name,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Update a lead label.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/LeadLabels#updateLeadLabel
+func (s *LeadLabelsService) Update(ctx context.Context, id string, opt *LeadLabelUpdateOptions) (*LeadLabelResponse, *Response, error) {
+	uri := fmt.Sprintf("/leadLabels/%v", id)
+	req, err := s.client.NewRequest(http.MethodPatch, uri, nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadLabelResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// Delete a lead label.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/LeadLabels#deleteLeadLabel
+func (s *LeadLabelsService) Delete(ctx context.Context, id string) (*Response, error) {
+	uri := fmt.Sprintf("/leadLabels/%v", id)
+	req, err := s.client.NewRequest(http.MethodDelete, uri, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
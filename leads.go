@@ -0,0 +1,469 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// LeadsService handles leads related
+// methods of the Pipedrive API.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads
+type LeadsService service
+
+// Lead represents a Pipedrive lead.
+type Lead struct {
+	ID                string                 `json:"id"`
+	Title             string                 `json:"title"`
+	OwnerID           int                    `json:"owner_id"`
+	CreatorID         int                    `json:"creator_id"`
+	LabelIDs          []string               `json:"label_ids"`
+	PersonID          *int                   `json:"person_id"`
+	OrganizationID    *int                   `json:"organization_id"`
+	SourceName        string                 `json:"source_name"`
+	Origin            string                 `json:"origin"`
+	Channel           interface{}            `json:"channel"`
+	IsArchived        bool                   `json:"is_archived"`
+	WasSeen           bool                   `json:"was_seen"`
+	Value             *Money                 `json:"value"`
+	ExpectedCloseDate *Date                  `json:"expected_close_date"`
+	NextActivityID    *int                   `json:"next_activity_id"`
+	AddTime           string                 `json:"add_time"`
+	UpdateTime        string                 `json:"update_time"`
+	VisibleTo         string                 `json:"visible_to"`
+	CcEmail           string                 `json:"cc_email"`
+	CustomFields      map[string]interface{} `json:"-"`
+}
+
+type _Lead Lead
+
+func (l Lead) String() string {
+	return Stringify(l)
+}
+
+func (l *Lead) UnmarshalJSON(b []byte) error {
+	obj := _Lead{}
+	err := json.Unmarshal(b, &obj)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, &(obj.CustomFields))
+	if err != nil {
+		return err
+	}
+
+	typ := reflect.TypeOf(obj)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		jsonTag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonTag != "" && jsonTag != "-" {
+			delete(obj.CustomFields, jsonTag)
+		}
+	}
+
+	*l = Lead(obj)
+
+	return nil
+}
+
+// LeadsResponse represents multiple leads response.
+type LeadsResponse struct {
+	Success        bool           `json:"success"`
+	Data           []Lead         `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// LeadResponse represents single lead response.
+type LeadResponse struct {
+	Success bool `json:"success"`
+	Data    Lead `json:"data"`
+}
+
+// LeadDeleteResponse represents a lead delete response.
+type LeadDeleteResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// LeadsListOptions specifices the optional parameters to the
+// LeadsService.List method.
+type LeadsListOptions struct {
+	ArchivedStatus string `url:"archived_status,omitempty"`
+	OwnerID        int    `url:"owner_id,omitempty"`
+	PersonID       int    `url:"person_id,omitempty"`
+	OrganizationID int    `url:"organization_id,omitempty"`
+	FilterID       int    `url:"filter_id,omitempty"`
+	Sort           string `url:"sort,omitempty"`
+	Start          int    `url:"start,omitempty"`
+	Limit          int    `url:"limit,omitempty"`
+}
+
+// List all leads.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#getLeads
+func (s *LeadsService) List(ctx context.Context, opt *LeadsListOptions) (*LeadsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/leads", opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// GetByID returns a specific lead.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#getLead
+func (s *LeadsService) GetByID(ctx context.Context, id string) (*LeadResponse, *Response, error) {
+	uri := fmt.Sprintf("/leads/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+type LeadSearchParams struct {
+	Term           string   `url:"term,omitempty"`
+	Fields         []string `url:"fields,omitempty,comma"`
+	ExactMatch     bool     `url:"exact_match,omitempty"`
+	PersonID       int      `url:"person_id,omitempty"`
+	OrganizationID int      `url:"organization_id,omitempty"`
+	IncludeFields  string   `url:"include_fields,omitempty"`
+	Start          int      `url:"start,omitempty"`
+	Limit          int      `url:"limit,omitempty"`
+}
+
+// LeadSearchItem represents a lead as returned by the search endpoint.
+type LeadSearchItem struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Owner struct {
+		ID int `json:"id"`
+	} `json:"owner"`
+	Person *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"person"`
+	Organization *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"organization"`
+	Phones       []string `json:"phones"`
+	Emails       []string `json:"emails"`
+	CustomFields []string `json:"custom_fields"`
+	Notes        []string `json:"notes"`
+	Value        float64  `json:"value"`
+	Currency     string   `json:"currency"`
+	VisibleTo    int      `json:"visible_to"`
+	IsArchived   bool     `json:"is_archived"`
+}
+
+type LeadSearchResult struct {
+	ResultScore float64        `json:"result_score"`
+	Item        LeadSearchItem `json:"item"`
+}
+
+type LeadsSearchResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Items []LeadSearchResult `json:"items"`
+	} `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// Search for lead(s)
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#searchLeads
+func (s *LeadsService) Search(ctx context.Context, searchParams LeadSearchParams) (*LeadsSearchResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/leads/search", searchParams, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response *LeadsSearchResponse
+	resp, err := s.client.Do(ctx, req, &response)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return response, resp, nil
+}
+
+// LeadCreateOptions specifices the optional parameters to the
+// LeadsService.Create method.
+type LeadCreateOptions struct {
+	Title             string    `json:"title,omitempty"`
+	OwnerID           uint      `json:"owner_id,omitempty"`
+	LabelIDs          []string  `json:"label_ids,omitempty"`
+	PersonID          uint      `json:"person_id,omitempty"`
+	OrganizationID    uint      `json:"organization_id,omitempty"`
+	Value             *Money    `json:"value,omitempty"`
+	ExpectedCloseDate Date      `json:"expected_close_date,omitempty"`
+	VisibleTo         VisibleTo `json:"visible_to,omitempty"`
+	WasSeen           bool      `json:"was_seen,omitempty"`
+
+	CustomFields map[string]interface{} `json:"-"`
+}
+
+func (l LeadCreateOptions) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	for k, v := range l.CustomFields {
+		fields[k] = v
+	}
+
+	if l.Title != "" {
+		fields["title"] = l.Title
+	}
+	if l.OwnerID != 0 {
+		fields["owner_id"] = l.OwnerID
+	}
+	if len(l.LabelIDs) > 0 {
+		fields["label_ids"] = l.LabelIDs
+	}
+	if l.PersonID != 0 {
+		fields["person_id"] = l.PersonID
+	}
+	if l.OrganizationID != 0 {
+		fields["organization_id"] = l.OrganizationID
+	}
+	if l.Value != nil {
+		fields["value"] = l.Value
+	}
+	if !l.ExpectedCloseDate.IsZero() {
+		fields["expected_close_date"] = l.ExpectedCloseDate
+	}
+	if l.VisibleTo != 0 {
+		fields["visible_to"] = fmt.Sprint(l.VisibleTo)
+	}
+	if l.WasSeen {
+		fields["was_seen"] = l.WasSeen
+	}
+
+	return json.Marshal(fields)
+}
+
+// Create a lead.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#addLead
+func (s *LeadsService) Create(ctx context.Context, opt *LeadCreateOptions) (*LeadResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, "/leads", nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// LeadUpdateOptions specifices the optional parameters to the
+// LeadsService.Update method.
+type LeadUpdateOptions struct {
+	Title             string    `json:"title,omitempty"`
+	OwnerID           uint      `json:"owner_id,omitempty"`
+	LabelIDs          []string  `json:"label_ids,omitempty"`
+	PersonID          uint      `json:"person_id,omitempty"`
+	OrganizationID    uint      `json:"organization_id,omitempty"`
+	IsArchived        *bool     `json:"is_archived,omitempty"`
+	Value             *Money    `json:"value,omitempty"`
+	ExpectedCloseDate Date      `json:"expected_close_date,omitempty"`
+	VisibleTo         VisibleTo `json:"visible_to,omitempty"`
+	WasSeen           *bool     `json:"was_seen,omitempty"`
+
+	CustomFields map[string]interface{} `json:"-"`
+}
+
+func (l LeadUpdateOptions) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	for k, v := range l.CustomFields {
+		fields[k] = v
+	}
+
+	if l.Title != "" {
+		fields["title"] = l.Title
+	}
+	if l.OwnerID != 0 {
+		fields["owner_id"] = l.OwnerID
+	}
+	if len(l.LabelIDs) > 0 {
+		fields["label_ids"] = l.LabelIDs
+	}
+	if l.PersonID != 0 {
+		fields["person_id"] = l.PersonID
+	}
+	if l.OrganizationID != 0 {
+		fields["organization_id"] = l.OrganizationID
+	}
+	if l.IsArchived != nil {
+		fields["is_archived"] = *l.IsArchived
+	}
+	if l.Value != nil {
+		fields["value"] = l.Value
+	}
+	if !l.ExpectedCloseDate.IsZero() {
+		fields["expected_close_date"] = l.ExpectedCloseDate
+	}
+	if l.VisibleTo != 0 {
+		fields["visible_to"] = fmt.Sprint(l.VisibleTo)
+	}
+	if l.WasSeen != nil {
+		fields["was_seen"] = *l.WasSeen
+	}
+
+	return json.Marshal(fields)
+}
+
+// Update a lead.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#updateLead
+func (s *LeadsService) Update(ctx context.Context, id string, opt *LeadUpdateOptions) (*LeadResponse, *Response, error) {
+	uri := fmt.Sprintf("/leads/%v", id)
+	req, err := s.client.NewRequest(http.MethodPatch, uri, nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// Delete a lead.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Leads#deleteLead
+func (s *LeadsService) Delete(ctx context.Context, id string) (*LeadDeleteResponse, *Response, error) {
+	uri := fmt.Sprintf("/leads/%v", id)
+	req, err := s.client.NewRequest(http.MethodDelete, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadDeleteResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// LeadConvertOptions specifices the optional parameters to the
+// LeadsService.ConvertToDeal method.
+type LeadConvertOptions struct {
+	StageID    uint `json:"stage_id,omitempty"`
+	PipelineID uint `json:"pipeline_id,omitempty"`
+}
+
+// Lead conversion statuses.
+const (
+	LeadConversionNotStarted = "not_started"
+	LeadConversionRunning    = "running"
+	LeadConversionCompleted  = "completed"
+	LeadConversionFailed     = "failed"
+	LeadConversionRejected   = "rejected"
+)
+
+// LeadConversion represents the state of a lead to deal conversion.
+type LeadConversion struct {
+	ConversionID string `json:"conversion_id"`
+	LeadID       string `json:"lead_id"`
+	DealID       int    `json:"deal_id"`
+	Status       string `json:"status"`
+}
+
+// LeadConversionResponse represents a lead conversion response.
+type LeadConversionResponse struct {
+	Success bool           `json:"success"`
+	Data    LeadConversion `json:"data"`
+}
+
+// ConvertToDeal starts the conversion of a lead into a deal. Conversion runs
+// asynchronously; poll GetConversionStatus with the returned conversion ID to
+// find the ID of the created deal.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v2/Leads#convertLeadToDeal
+func (s *LeadsService) ConvertToDeal(ctx context.Context, id string, opt *LeadConvertOptions) (*LeadConversionResponse, *Response, error) {
+	uri := fmt.Sprintf("/leads/%v/convert/deal", id)
+	req, err := s.client.newRequestV2(http.MethodPost, uri, nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadConversionResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// GetConversionStatus returns the state of a lead to deal conversion.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v2/Leads#getLeadConversionStatus
+func (s *LeadsService) GetConversionStatus(ctx context.Context, id string, conversionID string) (*LeadConversionResponse, *Response, error) {
+	uri := fmt.Sprintf("/leads/%v/convert/status/%v", id, conversionID)
+	req, err := s.client.newRequestV2(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *LeadConversionResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
@@ -0,0 +1,314 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// MailboxService handles mailbox related
+// methods of the Pipedrive API.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox
+type MailboxService service
+
+// Mail thread folders.
+const (
+	MailFolderInbox   = "inbox"
+	MailFolderDrafts  = "drafts"
+	MailFolderSent    = "sent"
+	MailFolderArchive = "archive"
+)
+
+// MailParticipant represents a sender or recipient of a mail message.
+// LinkedPersonID and LinkedOrganizationID refer to the Person and
+// Organization the email address is attached to, if any.
+type MailParticipant struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	EmailAddress         string `json:"email_address"`
+	LatestSent           bool   `json:"latest_sent"`
+	MessageTime          int64  `json:"message_time"`
+	LinkedPersonID       *int   `json:"linked_person_id"`
+	LinkedPersonName     string `json:"linked_person_name"`
+	LinkedOrganizationID *int   `json:"linked_organization_id"`
+	MailMessagePartyID   int    `json:"mail_message_party_id"`
+}
+
+// MailThreadParties represents the participants of a mail thread.
+type MailThreadParties struct {
+	To   []MailParticipant `json:"to"`
+	From []MailParticipant `json:"from"`
+}
+
+// MailThread represents a Pipedrive mail thread. DealID and LeadID refer to
+// the Deal and Lead the thread is linked to, if any.
+type MailThread struct {
+	ID                           int               `json:"id"`
+	AccountID                    string            `json:"account_id"`
+	UserID                       int               `json:"user_id"`
+	Subject                      string            `json:"subject"`
+	Snippet                      string            `json:"snippet"`
+	SnippetDraft                 string            `json:"snippet_draft"`
+	SnippetSent                  string            `json:"snippet_sent"`
+	Parties                      MailThreadParties `json:"parties"`
+	DraftsParties                []interface{}     `json:"drafts_parties"`
+	Folders                      []string          `json:"folders"`
+	Version                      float64           `json:"version"`
+	MessageCount                 int               `json:"message_count"`
+	ReadFlag                     int               `json:"read_flag"`
+	MailTrackingStatus           string            `json:"mail_tracking_status"`
+	MailLinkTrackingEnabledFlag  int               `json:"mail_link_tracking_enabled_flag"`
+	HasAttachmentsFlag           int               `json:"has_attachments_flag"`
+	HasInlineAttachmentsFlag     int               `json:"has_inline_attachments_flag"`
+	HasRealAttachmentsFlag       int               `json:"has_real_attachments_flag"`
+	HasDraftFlag                 int               `json:"has_draft_flag"`
+	HasSentFlag                  int               `json:"has_sent_flag"`
+	ArchivedFlag                 int               `json:"archived_flag"`
+	SharedFlag                   int               `json:"shared_flag"`
+	DeletedFlag                  int               `json:"deleted_flag"`
+	SyncedFlag                   int               `json:"synced_flag"`
+	SmartBccFlag                 int               `json:"smart_bcc_flag"`
+	ExternalDeletedFlag          int               `json:"external_deleted_flag"`
+	FirstMessageToMeFlag         int               `json:"first_message_to_me_flag"`
+	AllMessagesSentFlag          int               `json:"all_messages_sent_flag"`
+	LastMessageTimestamp         string            `json:"last_message_timestamp"`
+	FirstMessageTimestamp        string            `json:"first_message_timestamp"`
+	LastMessageSentTimestamp     string            `json:"last_message_sent_timestamp"`
+	LastMessageReceivedTimestamp string            `json:"last_message_received_timestamp"`
+	AddTime                      string            `json:"add_time"`
+	UpdateTime                   string            `json:"update_time"`
+	DealID                       *int              `json:"deal_id"`
+	DealStatus                   string            `json:"deal_status"`
+	LeadID                       *string           `json:"lead_id"`
+}
+
+func (m MailThread) String() string {
+	return Stringify(m)
+}
+
+// MailMessage represents a Pipedrive mail message. Body is only populated
+// when the message is requested with its body.
+type MailMessage struct {
+	ID                          int               `json:"id"`
+	From                        []MailParticipant `json:"from"`
+	To                          []MailParticipant `json:"to"`
+	Cc                          []MailParticipant `json:"cc"`
+	Bcc                         []MailParticipant `json:"bcc"`
+	BodyURL                     string            `json:"body_url"`
+	Body                        string            `json:"body,omitempty"`
+	AccountID                   string            `json:"account_id"`
+	UserID                      int               `json:"user_id"`
+	MailThreadID                int               `json:"mail_thread_id"`
+	Subject                     string            `json:"subject"`
+	Snippet                     string            `json:"snippet"`
+	MailTrackingStatus          string            `json:"mail_tracking_status"`
+	MailLinkTrackingEnabledFlag int               `json:"mail_link_tracking_enabled_flag"`
+	ReadFlag                    int               `json:"read_flag"`
+	Draft                       string            `json:"draft"`
+	DraftFlag                   int               `json:"draft_flag"`
+	SyncedFlag                  int               `json:"synced_flag"`
+	DeletedFlag                 int               `json:"deleted_flag"`
+	HasBodyFlag                 int               `json:"has_body_flag"`
+	SentFlag                    int               `json:"sent_flag"`
+	SentFromPipedriveFlag       int               `json:"sent_from_pipedrive_flag"`
+	SmartBccFlag                int               `json:"smart_bcc_flag"`
+	HasAttachmentsFlag          int               `json:"has_attachments_flag"`
+	HasInlineAttachmentsFlag    int               `json:"has_inline_attachments_flag"`
+	HasRealAttachmentsFlag      int               `json:"has_real_attachments_flag"`
+	MessageTime                 string            `json:"message_time"`
+	AddTime                     string            `json:"add_time"`
+	UpdateTime                  string            `json:"update_time"`
+}
+
+func (m MailMessage) String() string {
+	return Stringify(m)
+}
+
+// MailThreadsResponse represents multiple mail threads response.
+type MailThreadsResponse struct {
+	Success        bool           `json:"success"`
+	Data           []MailThread   `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// MailThreadResponse represents single mail thread response.
+type MailThreadResponse struct {
+	Success bool       `json:"success"`
+	Data    MailThread `json:"data"`
+}
+
+// MailMessagesResponse represents multiple mail messages response.
+type MailMessagesResponse struct {
+	Success        bool           `json:"success"`
+	Data           []MailMessage  `json:"data"`
+	AdditionalData AdditionalData `json:"additional_data"`
+}
+
+// MailMessageResponse represents single mail message response.
+type MailMessageResponse struct {
+	Success bool        `json:"success"`
+	Data    MailMessage `json:"data"`
+}
+
+// MailThreadsListOptions specifices the optional parameters to the
+// MailboxService.ListThreads method.
+type MailThreadsListOptions struct {
+	Folder string `url:"folder"`
+	Start  int    `url:"start,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+}
+
+// ListThreads returns the mail threads in a folder.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#getMailThreads
+func (s *MailboxService) ListThreads(ctx context.Context, opt *MailThreadsListOptions) (*MailThreadsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/mailbox/mailThreads", opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailThreadsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// GetThread returns a specific mail thread.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#getMailThread
+func (s *MailboxService) GetThread(ctx context.Context, id int) (*MailThreadResponse, *Response, error) {
+	uri := fmt.Sprintf("/mailbox/mailThreads/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailThreadResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// MailThreadUpdateOptions specifices the optional parameters to the
+// MailboxService.UpdateThread method.
+type MailThreadUpdateOptions struct {
+	DealID       uint   `json:"deal_id,omitempty"`
+	LeadID       string `json:"lead_id,omitempty"`
+	SharedFlag   *uint8 `json:"shared_flag,omitempty"`
+	ReadFlag     *uint8 `json:"read_flag,omitempty"`
+	ArchivedFlag *uint8 `json:"archived_flag,omitempty"`
+}
+
+// UpdateThread updates the properties of a mail thread, such as the deal or
+// lead it is linked to.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#updateMailThreadDetails
+func (s *MailboxService) UpdateThread(ctx context.Context, id int, opt *MailThreadUpdateOptions) (*MailThreadResponse, *Response, error) {
+	uri := fmt.Sprintf("/mailbox/mailThreads/%v", id)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailThreadResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// DeleteThread marks a mail thread as deleted.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#deleteMailThread
+func (s *MailboxService) DeleteThread(ctx context.Context, id int) (*Response, error) {
+	uri := fmt.Sprintf("/mailbox/mailThreads/%v", id)
+	req, err := s.client.NewRequest(http.MethodDelete, uri, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// ListThreadMessages returns the mail messages inside a mail thread.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#getMailThreadMessages
+func (s *MailboxService) ListThreadMessages(ctx context.Context, id int) (*MailMessagesResponse, *Response, error) {
+	uri := fmt.Sprintf("/mailbox/mailThreads/%v/mailMessages", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailMessagesResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// MailMessageGetOptions specifices the optional parameters to the
+// MailboxService.GetMessage method.
+type MailMessageGetOptions struct {
+	IncludeBody uint8 `url:"include_body,omitempty"`
+}
+
+// GetMessage returns a specific mail message.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Mailbox#getMailMessage
+func (s *MailboxService) GetMessage(ctx context.Context, id int, opt *MailMessageGetOptions) (*MailMessageResponse, *Response, error) {
+	uri := fmt.Sprintf("/mailbox/mailMessages/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailMessageResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// MailMessageItem represents a mail message attached to a deal or person.
+type MailMessageItem struct {
+	ObjectType string      `json:"object_type"`
+	Timestamp  string      `json:"timestamp"`
+	Data       MailMessage `json:"data"`
+}
+
+// MailMessageItemsResponse represents the mail messages of a deal or person.
+type MailMessageItemsResponse struct {
+	Success        bool              `json:"success"`
+	Data           []MailMessageItem `json:"data"`
+	AdditionalData AdditionalData    `json:"additional_data"`
+}
@@ -12,28 +12,10 @@ import (
 type NoteFieldsService service
 
 // NoteField represents a Pipedrive note field.
-type NoteField struct {
-	ID                   int      `json:"id,omitempty"`
-	Key                  string   `json:"key,omitempty"`
-	Name                 string   `json:"name,omitempty"`
-	ActiveFlag           bool     `json:"active_flag,omitempty"`
-	FieldType            string   `json:"field_type,omitempty"`
-	EditFlag             int      `json:"edit_flag,omitempty"`
-	MandatoryFlag        bool     `json:"mandatory_flag,omitempty"`
-	VisibleInExportsFlag bool     `json:"visible_in_exports_flag,omitempty"`
-	Options              []Option `json:"options,omitempty"`
-}
-
-func (nf NoteField) String() string {
-	return Stringify(nf)
-}
+type NoteField = EntityField
 
-// NoteFieldsResponse represents multiple note fields esponse.
-type NoteFieldsResponse struct {
-	Success        bool           `json:"success,omitempty"`
-	Data           []NoteField    `json:"data,omitempty"`
-	AdditionalData AdditionalData `json:"additional_data,omitempty"`
-}
+// NoteFieldsResponse represents multiple note fields response.
+type NoteFieldsResponse = EntityFieldsResponse
 
 // List returns all fields for note.
 //
@@ -55,3 +37,32 @@ func (s *NoteFieldsService) List(ctx context.Context) (*NoteFieldsResponse, *Res
 
 	return record, resp, nil
 }
+
+// ListFields returns all note fields. It implements FieldsAPI.
+func (s *NoteFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_NOTE)
+}
+
+// GetField returns the note field with the given ID. It implements
+// FieldsAPI.
+func (s *NoteFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_NOTE, id)
+}
+
+// CreateField fails, as note fields cannot be edited. It implements
+// FieldsAPI.
+func (s *NoteFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_NOTE, opt)
+}
+
+// UpdateField fails, as note fields cannot be edited. It implements
+// FieldsAPI.
+func (s *NoteFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_NOTE, id, opt)
+}
+
+// DeleteField fails, as note fields cannot be edited. It implements
+// FieldsAPI.
+func (s *NoteFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_NOTE, id)
+}
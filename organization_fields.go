@@ -13,48 +13,13 @@ import (
 type OrganizationFieldsService service
 
 // OrganizationField represents a Pipedrive organization field.
-type OrganizationField struct {
-	ID                 int         `json:"id"`
-	Key                string      `json:"key"`
-	Name               string      `json:"name"`
-	OrderNr            int         `json:"order_nr,omitempty"`
-	PicklistData       interface{} `json:"picklist_data,omitempty"`
-	FieldType          FieldType   `json:"field_type"`
-	AddTime            string      `json:"add_time,omitempty"`
-	UpdateTime         string      `json:"update_time,omitempty"`
-	ActiveFlag         bool        `json:"active_flag"`
-	EditFlag           bool        `json:"edit_flag"`
-	IndexVisibleFlag   bool        `json:"index_visible_flag,omitempty"`
-	DetailsVisibleFlag bool        `json:"details_visible_flag,omitempty"`
-	AddVisibleFlag     bool        `json:"add_visible_flag,omitempty"`
-	ImportantFlag      bool        `json:"important_flag,omitempty"`
-	BulkEditAllowed    bool        `json:"bulk_edit_allowed,omitempty"`
-	SearchableFlag     bool        `json:"searchable_flag,omitempty"`
-	UseField           string      `json:"use_field,omitempty"`
-	Link               string      `json:"link,omitempty"`
-	MandatoryFlag      bool        `json:"mandatory_flag"`
-	DisplayField       string      `json:"display_field,omitempty"`
-	Options            []Option    `json:"options,omitempty"`
-	IsSubfield         bool        `json:"is_subfield,omitempty"`
-}
-
-func (of OrganizationField) String() string {
-	return Stringify(of)
-}
+type OrganizationField = EntityField
 
 // OrganizationFieldsResponse represents multiple organization fields response.
-type OrganizationFieldsResponse struct {
-	Success        bool                `json:"success"`
-	Data           []OrganizationField `json:"data"`
-	AdditionalData AdditionalData      `json:"additional_data"`
-}
+type OrganizationFieldsResponse = EntityFieldsResponse
 
 // OrganizationFieldResponse represents single organization field response.
-type OrganizationFieldResponse struct {
-	Success        bool              `json:"success"`
-	Data           OrganizationField `json:"data"`
-	AdditionalData AdditionalData    `json:"additional_data"`
-}
+type OrganizationFieldResponse = EntityFieldResponse
 
 // List all organization fields within company.
 //
@@ -102,16 +67,28 @@ func (s *OrganizationFieldsService) GetByID(ctx context.Context, id int) (*Organ
 // OrganizationFieldCreateOptions specifices the optional parameters to the
 // OrganizationFieldsService.Create method.
 type OrganizationFieldCreateOptions struct {
-	Name      string    `url:"name"`
-	FieldType FieldType `url:"field_type"`
-	Options   string    `url:"options"`
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+
+	// Options are the labels of the options of enum and set fields.
+	Options []string `json:"-"`
 }
 
 // Create a new organization field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/OrganizationFields/post_organizationFields
 func (s *OrganizationFieldsService) Create(ctx context.Context, opt *OrganizationFieldCreateOptions) (*OrganizationFieldResponse, *Response, error) {
-	req, err := s.client.NewRequest(http.MethodPost, "/organizationFields", nil, opt)
+	if opt == nil {
+		opt = &OrganizationFieldCreateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "/organizationFields", nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -131,16 +108,30 @@ func (s *OrganizationFieldsService) Create(ctx context.Context, opt *Organizatio
 // OrganizationFieldUpdateOptions specifices the optional parameters to the
 // OrganizationFieldsService.Update method.
 type OrganizationFieldUpdateOptions struct {
-	Name    string `url:"name"`
-	Options string `url:"options"`
+	Name string `json:"name"`
+
+	// Options replace the options of enum and set fields. Existing options
+	// must be given with their ID, options left out being deleted; new
+	// options only need a label.
+	Options []Option `json:"-"`
 }
 
 // Update a specific organization field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/OrganizationFields/put_organizationFields_id
 func (s *OrganizationFieldsService) Update(ctx context.Context, id int, opt *OrganizationFieldUpdateOptions) (*OrganizationFieldResponse, *Response, error) {
+	if opt == nil {
+		opt = &OrganizationFieldUpdateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	uri := fmt.Sprintf("/organizationFields/%v", id)
-	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -185,3 +176,55 @@ func (s *OrganizationFieldsService) Delete(ctx context.Context, id int) (*Respon
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// AddOption adds an option labeled label to an enum or set organization field,
+// keeping the IDs of the other options.
+func (s *OrganizationFieldsService) AddOption(ctx context.Context, id int, label string) (*FieldDefinition, error) {
+	return s.client.addFieldOption(ctx, OBJECT_ORGANIZATION, id, label)
+}
+
+// RenameOption relabels an option of an enum or set organization field, keeping
+// its ID and the values of the records using it.
+func (s *OrganizationFieldsService) RenameOption(ctx context.Context, id int, optionID int, label string) (*FieldDefinition, error) {
+	return s.client.renameFieldOption(ctx, OBJECT_ORGANIZATION, id, optionID, label)
+}
+
+// ReorderOptions orders the options of an enum or set organization field as
+// optionIDs, which must list every option.
+func (s *OrganizationFieldsService) ReorderOptions(ctx context.Context, id int, optionIDs []int) (*FieldDefinition, error) {
+	return s.client.reorderFieldOptions(ctx, OBJECT_ORGANIZATION, id, optionIDs)
+}
+
+// RemoveOption removes an option of an enum or set organization field. If
+// migrateTo is not 0, the organizations using the option are first moved to the
+// option with that ID, and the option is kept if any cannot be moved. The
+// last option of a field cannot be removed.
+func (s *OrganizationFieldsService) RemoveOption(ctx context.Context, id int, optionID int, migrateTo int) (*FieldOptionRemoval, error) {
+	return s.client.removeFieldOption(ctx, OBJECT_ORGANIZATION, id, optionID, migrateTo)
+}
+
+// ListFields returns all organization fields. It implements FieldsAPI.
+func (s *OrganizationFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_ORGANIZATION)
+}
+
+// GetField returns the organization field with the given ID. It implements
+// FieldsAPI.
+func (s *OrganizationFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_ORGANIZATION, id)
+}
+
+// CreateField creates a organization field. It implements FieldsAPI.
+func (s *OrganizationFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_ORGANIZATION, opt)
+}
+
+// UpdateField updates a organization field. It implements FieldsAPI.
+func (s *OrganizationFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_ORGANIZATION, id, opt)
+}
+
+// DeleteField deletes a organization field. It implements FieldsAPI.
+func (s *OrganizationFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_ORGANIZATION, id)
+}
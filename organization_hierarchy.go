@@ -0,0 +1,302 @@
+package pipedrive
+
+import (
+	"context"
+)
+
+// OrganizationRollup holds counts aggregated over one or more organizations.
+type OrganizationRollup struct {
+	Organizations    int                `json:"organizations"`
+	PeopleCount      int                `json:"people_count"`
+	OpenDealsCount   int                `json:"open_deals_count"`
+	ClosedDealsCount int                `json:"closed_deals_count"`
+	WonDealsCount    int                `json:"won_deals_count"`
+	LostDealsCount   int                `json:"lost_deals_count"`
+	ActivitiesCount  int                `json:"activities_count"`
+	WonValue         map[string]float64 `json:"won_value,omitempty"`
+}
+
+func (r *OrganizationRollup) add(o OrganizationRollup) {
+	r.Organizations += o.Organizations
+	r.PeopleCount += o.PeopleCount
+	r.OpenDealsCount += o.OpenDealsCount
+	r.ClosedDealsCount += o.ClosedDealsCount
+	r.WonDealsCount += o.WonDealsCount
+	r.LostDealsCount += o.LostDealsCount
+	r.ActivitiesCount += o.ActivitiesCount
+
+	for currency, value := range o.WonValue {
+		if r.WonValue == nil {
+			r.WonValue = map[string]float64{}
+		}
+		r.WonValue[currency] += value
+	}
+}
+
+// OrganizationNode is an organization within an organization hierarchy.
+type OrganizationNode struct {
+	Organization  Organization        `json:"organization"`
+	Parent        *OrganizationNode   `json:"-"`
+	Children      []*OrganizationNode `json:"children,omitempty"`
+	RelatedOrgIDs []int               `json:"related_org_ids,omitempty"`
+
+	// Own holds the counts of this organization alone, Total those of the
+	// organization and all of its subsidiaries.
+	Own   OrganizationRollup `json:"own"`
+	Total OrganizationRollup `json:"total"`
+}
+
+func (n *OrganizationNode) String() string {
+	return Stringify(n)
+}
+
+// Walk calls fn for the node and each of its descendants, depth first.
+func (n *OrganizationNode) Walk(fn func(node *OrganizationNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *OrganizationNode) walk(fn func(node *OrganizationNode, depth int), depth int) {
+	fn(n, depth)
+
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// Find returns the node of the organization with the given ID, or nil.
+func (n *OrganizationNode) Find(id int) *OrganizationNode {
+	if n.Organization.ID == id {
+		return n
+	}
+
+	for _, child := range n.Children {
+		if found := child.Find(id); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// OrganizationHierarchyOptions specifices the optional parameters to the
+// OrganizationRelationshipsService.BuildHierarchy method.
+type OrganizationHierarchyOptions struct {
+	// IncludeWonValue sums the value of won deals, per currency, into the
+	// rollups. This lists the won deals of every organization in the tree.
+	IncludeWonValue bool
+}
+
+type hierarchyBuilder struct {
+	client        *Client
+	opt           OrganizationHierarchyOptions
+	organizations map[int]Organization
+	relationships map[int][]OrganizationRelationship
+	seen          map[int]bool
+}
+
+// BuildHierarchy builds the full hierarchy tree containing org and returns
+// its root. Parent relationships are followed up from org to the topmost
+// parent, then down to every subsidiary. An OrganizationHierarchyCycleError
+// is returned if the parent relationships form a cycle.
+func (s *OrganizationRelationshipsService) BuildHierarchy(ctx context.Context, org Organization, opt *OrganizationHierarchyOptions) (*OrganizationNode, error) {
+	b := &hierarchyBuilder{
+		client:        s.client,
+		organizations: map[int]Organization{org.ID: org},
+		relationships: map[int][]OrganizationRelationship{},
+		seen:          map[int]bool{},
+	}
+
+	if opt != nil {
+		b.opt = *opt
+	}
+
+	rootID, err := b.findRoot(ctx, org.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.build(ctx, rootID, nil, []int{})
+}
+
+func (b *hierarchyBuilder) findRoot(ctx context.Context, id int) (int, error) {
+	path := []int{id}
+	onPath := map[int]bool{id: true}
+
+	for {
+		relationships, err := b.listRelationships(ctx, id)
+
+		if err != nil {
+			return 0, err
+		}
+
+		parentID := 0
+
+		for _, r := range relationships {
+			if r.Type == OrganizationRelationshipParent && r.RelLinkedOrgID.Value == id {
+				parentID = r.RelOwnerOrgID.Value
+				break
+			}
+		}
+
+		if parentID == 0 {
+			return id, nil
+		}
+
+		if onPath[parentID] {
+			return 0, &OrganizationHierarchyCycleError{Path: cyclePath(path, parentID)}
+		}
+
+		path = append(path, parentID)
+		onPath[parentID] = true
+		id = parentID
+	}
+}
+
+func (b *hierarchyBuilder) build(ctx context.Context, id int, parent *OrganizationNode, ancestors []int) (*OrganizationNode, error) {
+	org, err := b.getOrganization(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b.seen[id] = true
+	ancestors = append(ancestors, id)
+
+	node := &OrganizationNode{
+		Organization: org,
+		Parent:       parent,
+		Own: OrganizationRollup{
+			Organizations:    1,
+			PeopleCount:      org.PeopleCount,
+			OpenDealsCount:   org.OpenDealsCount,
+			ClosedDealsCount: org.ClosedDealsCount,
+			WonDealsCount:    org.WonDealsCount,
+			LostDealsCount:   org.LostDealsCount,
+			ActivitiesCount:  org.ActivitiesCount,
+		},
+	}
+
+	if b.opt.IncludeWonValue {
+		node.Own.WonValue, err = b.wonValue(ctx, id)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	node.Total.add(node.Own)
+
+	relationships, err := b.listRelationships(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range relationships {
+		switch {
+		case r.Type == OrganizationRelationshipRelated:
+			relatedID := r.RelLinkedOrgID.Value
+			if relatedID == id {
+				relatedID = r.RelOwnerOrgID.Value
+			}
+			node.RelatedOrgIDs = append(node.RelatedOrgIDs, relatedID)
+
+		case r.Type == OrganizationRelationshipParent && r.RelOwnerOrgID.Value == id:
+			childID := r.RelLinkedOrgID.Value
+
+			for _, ancestor := range ancestors {
+				if ancestor == childID {
+					return nil, &OrganizationHierarchyCycleError{Path: cyclePath(ancestors, childID)}
+				}
+			}
+
+			if b.seen[childID] {
+				continue
+			}
+
+			child, err := b.build(ctx, childID, node, ancestors)
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.Children = append(node.Children, child)
+			node.Total.add(child.Total)
+		}
+	}
+
+	return node, nil
+}
+
+func (b *hierarchyBuilder) listRelationships(ctx context.Context, id int) ([]OrganizationRelationship, error) {
+	if relationships, ok := b.relationships[id]; ok {
+		return relationships, nil
+	}
+
+	record, _, err := b.client.OrganizationRelationships.List(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b.relationships[id] = record.Data
+
+	return record.Data, nil
+}
+
+func (b *hierarchyBuilder) getOrganization(ctx context.Context, id int) (Organization, error) {
+	if org, ok := b.organizations[id]; ok {
+		return org, nil
+	}
+
+	record, _, err := b.client.Organizations.GetByID(ctx, id)
+
+	if err != nil {
+		return Organization{}, err
+	}
+
+	b.organizations[id] = record.Data
+
+	return record.Data, nil
+}
+
+func (b *hierarchyBuilder) wonValue(ctx context.Context, id int) (map[string]float64, error) {
+	values := map[string]float64{}
+	opt := &OrganizationDealsListOptions{
+		Status: "won",
+		Limit:  500,
+	}
+
+	for {
+		record, _, err := b.client.Organizations.ListDeals(ctx, id, opt)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, deal := range record.Data {
+			values[deal.Currency] += deal.Value
+		}
+
+		pagination := record.AdditionalData.Pagination
+
+		if !pagination.MoreItemsInCollection {
+			return values, nil
+		}
+
+		opt.Start = pagination.NextStart
+	}
+}
+
+// cyclePath returns the part of path starting at id, closed with id.
+func cyclePath(path []int, id int) []int {
+	for i, v := range path {
+		if v == id {
+			cycle := append([]int{}, path[i:]...)
+			return append(cycle, id)
+		}
+	}
+
+	return append(append([]int{}, path...), id)
+}
@@ -0,0 +1,147 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// hierarchyTestServer serves organizations, with counts derived from their
+// ID, and the relationships listed as [type, owner, linked] triples.
+func hierarchyTestServer(t *testing.T, relationships [][3]interface{}) *Client {
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/organizationRelationships":
+			id, _ := strconv.Atoi(r.URL.Query().Get("org_id"))
+
+			var data []map[string]interface{}
+
+			for i, relationship := range relationships {
+				owner, linked := relationship[1].(int), relationship[2].(int)
+
+				if owner != id && linked != id {
+					continue
+				}
+
+				data = append(data, map[string]interface{}{
+					"id":                i + 1,
+					"type":              relationship[0],
+					"rel_owner_org_id":  map[string]interface{}{"value": owner},
+					"rel_linked_org_id": map[string]interface{}{"value": linked},
+				})
+			}
+
+			json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": data})
+
+		case strings.HasSuffix(r.URL.Path, "/deals"):
+			switch r.URL.Path {
+			case "/v1/organizations/2/deals":
+				w.Write([]byte(`{"success": true, "data": [{"id": 1, "value": 50, "currency": "EUR"}]}`))
+			case "/v1/organizations/4/deals":
+				w.Write([]byte(`{"success": true, "data": [{"id": 2, "value": 100, "currency": "USD"}, {"id": 3, "value": 20, "currency": "EUR"}]}`))
+			default:
+				w.Write([]byte(`{"success": true, "data": []}`))
+			}
+
+		case strings.HasPrefix(r.URL.Path, "/v1/organizations/"):
+			id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/v1/organizations/"))
+
+			fmt.Fprintf(w, `{"success": true, "data": {"id": %d, "people_count": %d, "open_deals_count": %d, "won_deals_count": 1}}`, id, id, id*10)
+
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestOrganizationRelationshipsService_BuildHierarchy(t *testing.T) {
+	client := hierarchyTestServer(t, [][3]interface{}{
+		{OrganizationRelationshipParent, 1, 2},
+		{OrganizationRelationshipParent, 1, 3},
+		{OrganizationRelationshipParent, 2, 4},
+		{OrganizationRelationshipRelated, 3, 4},
+	})
+
+	org := Organization{ID: 4, PeopleCount: 4, OpenDealsCount: 40, WonDealsCount: 1}
+
+	root, err := client.OrganizationRelationships.BuildHierarchy(context.Background(), org, &OrganizationHierarchyOptions{IncludeWonValue: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if root.Organization.ID != 1 || len(root.Children) != 2 {
+		t.Fatalf("Got root %v", root)
+	}
+
+	want := OrganizationRollup{
+		Organizations:  4,
+		PeopleCount:    10,
+		OpenDealsCount: 100,
+		WonDealsCount:  4,
+		WonValue:       map[string]float64{"EUR": 70, "USD": 100},
+	}
+
+	if !reflect.DeepEqual(root.Total, want) {
+		t.Errorf("Got total %v, want %v", root.Total, want)
+	}
+
+	node := root.Find(2)
+
+	if node == nil || node.Total.Organizations != 2 || node.Total.PeopleCount != 6 || node.Own.PeopleCount != 2 {
+		t.Errorf("Got node %v", node)
+	}
+
+	if node := root.Find(4); node == nil || node.Parent.Organization.ID != 2 || !reflect.DeepEqual(node.RelatedOrgIDs, []int{3}) {
+		t.Errorf("Got node %v", node)
+	}
+}
+
+func TestOrganizationRelationshipsService_BuildHierarchyCycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		relationships [][3]interface{}
+		org           int
+		want          []int
+	}{
+		{
+			"parents",
+			[][3]interface{}{
+				{OrganizationRelationshipParent, 5, 6},
+				{OrganizationRelationshipParent, 6, 7},
+				{OrganizationRelationshipParent, 7, 5},
+			},
+			6,
+			[]int{6, 5, 7, 6},
+		},
+		{
+			"subsidiaries",
+			[][3]interface{}{
+				{OrganizationRelationshipParent, 1, 2},
+				{OrganizationRelationshipParent, 2, 3},
+				{OrganizationRelationshipParent, 3, 2},
+			},
+			1,
+			[]int{2, 3, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		client := hierarchyTestServer(t, tt.relationships)
+
+		root, err := client.OrganizationRelationships.BuildHierarchy(context.Background(), Organization{ID: tt.org}, nil)
+
+		var cycleError *OrganizationHierarchyCycleError
+
+		if !errors.As(err, &cycleError) || !reflect.DeepEqual(cycleError.Path, tt.want) {
+			t.Errorf("%s: got root %v and error %v, want a cycle %v", tt.name, root, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OrganizationRelationshipsService handles organization relationships related
+// methods of the Pipedrive API.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships
+type OrganizationRelationshipsService service
+
+// Organization relationship types.
+const (
+	OrganizationRelationshipParent  = "parent"
+	OrganizationRelationshipRelated = "related"
+)
+
+// RelationshipOrganization represents an organization referenced by a relationship.
+type RelationshipOrganization struct {
+	Name        string      `json:"name"`
+	PeopleCount int         `json:"people_count"`
+	OwnerID     int         `json:"owner_id"`
+	Address     interface{} `json:"address"`
+	ActiveFlag  bool        `json:"active_flag"`
+	CcEmail     string      `json:"cc_email"`
+	Value       int         `json:"value"`
+}
+
+// OrganizationRelationship represents a Pipedrive organization relationship.
+//
+// For relationships of type parent, RelOwnerOrgID is the parent organization
+// and RelLinkedOrgID its daughter.
+type OrganizationRelationship struct {
+	ID                      int                      `json:"id"`
+	Type                    string                   `json:"type"`
+	RelOwnerOrgID           RelationshipOrganization `json:"rel_owner_org_id"`
+	RelLinkedOrgID          RelationshipOrganization `json:"rel_linked_org_id"`
+	AddTime                 string                   `json:"add_time"`
+	UpdateTime              string                   `json:"update_time"`
+	ActiveFlag              bool                     `json:"active_flag"`
+	CalculatedType          string                   `json:"calculated_type"`
+	CalculatedRelatedOrgID  int                      `json:"calculated_related_org_id"`
+	RelatedOrganizationName string                   `json:"related_organization_name"`
+}
+
+func (o OrganizationRelationship) String() string {
+	return Stringify(o)
+}
+
+// OrganizationRelationshipsResponse represents multiple organization relationships response.
+type OrganizationRelationshipsResponse struct {
+	Success        bool                       `json:"success"`
+	Data           []OrganizationRelationship `json:"data"`
+	AdditionalData AdditionalData             `json:"additional_data"`
+}
+
+// OrganizationRelationshipResponse represents single organization relationship response.
+type OrganizationRelationshipResponse struct {
+	Success bool                     `json:"success"`
+	Data    OrganizationRelationship `json:"data"`
+}
+
+// OrganizationRelationshipsListOptions specifices the optional parameters to the
+// OrganizationRelationshipsService.List and OrganizationRelationshipsService.GetByID methods.
+type OrganizationRelationshipsListOptions struct {
+	OrgID int `url:"org_id,omitempty"`
+}
+
+// List all relationships of an organization.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships#getOrganizationRelationships
+func (s *OrganizationRelationshipsService) List(ctx context.Context, orgID int) (*OrganizationRelationshipsResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/organizationRelationships", &OrganizationRelationshipsListOptions{
+		OrgID: orgID,
+	}, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *OrganizationRelationshipsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// GetByID returns a specific organization relationship. The calculated
+// fields of the relationship are computed relative to the organization in opt.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships#getOrganizationRelationship
+func (s *OrganizationRelationshipsService) GetByID(ctx context.Context, id int, opt *OrganizationRelationshipsListOptions) (*OrganizationRelationshipResponse, *Response, error) {
+	uri := fmt.Sprintf("/organizationRelationships/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *OrganizationRelationshipResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// OrganizationRelationshipCreateOptions specifices the optional parameters to the
+// OrganizationRelationshipsService.Create and OrganizationRelationshipsService.Update methods.
+type OrganizationRelationshipCreateOptions struct {
+	OrgID          uint   `json:"org_id,omitempty"`
+	Type           string `json:"type,omitempty"`
+	RelOwnerOrgID  uint   `json:"rel_owner_org_id,omitempty"`
+	RelLinkedOrgID uint   `json:"rel_linked_org_id,omitempty"`
+}
+
+// Create an organization relationship.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships#addOrganizationRelationship
+func (s *OrganizationRelationshipsService) Create(ctx context.Context, opt *OrganizationRelationshipCreateOptions) (*OrganizationRelationshipResponse, *Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, "/organizationRelationships", nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *OrganizationRelationshipResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// Update an organization relationship.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships#updateOrganizationRelationship
+func (s *OrganizationRelationshipsService) Update(ctx context.Context, id int, opt *OrganizationRelationshipCreateOptions) (*OrganizationRelationshipResponse, *Response, error) {
+	uri := fmt.Sprintf("/organizationRelationships/%v", id)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *OrganizationRelationshipResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// Delete an organization relationship.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/OrganizationRelationships#deleteOrganizationRelationship
+func (s *OrganizationRelationshipsService) Delete(ctx context.Context, id int) (*Response, error) {
+	uri := fmt.Sprintf("/organizationRelationships/%v", id)
+	req, err := s.client.NewRequest(http.MethodDelete, uri, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -152,6 +152,60 @@ func (s *OrganizationsService) List(ctx context.Context, opts *OrganizationsList
 	return record, resp, nil
 }
 
+// GetByID returns a specific organization.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Organizations/get_organizations_id
+func (s *OrganizationsService) GetByID(ctx context.Context, id int) (*OrganizationResponse, *Response, error) {
+	uri := fmt.Sprintf("/organizations/%v", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, nil, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *OrganizationResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
+// OrganizationDealsListOptions specifices the optional parameters to the
+// OrganizationsService.ListDeals method.
+type OrganizationDealsListOptions struct {
+	Status                 string `url:"status,omitempty"`
+	Sort                   string `url:"sort,omitempty"`
+	OnlyPrimaryAssociation int    `url:"only_primary_association,omitempty"`
+	Start                  int    `url:"start,omitempty"`
+	Limit                  int    `url:"limit,omitempty"`
+}
+
+// ListDeals lists deals associated with an organization.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Organizations/get_organizations_id_deals
+func (s *OrganizationsService) ListDeals(ctx context.Context, id int, opt *OrganizationDealsListOptions) (*DealsResponse, *Response, error) {
+	uri := fmt.Sprintf("/organizations/%v/deals", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *DealsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
 type OrganizationSearchParams struct {
 	Term       string   `url:"term,omitempty"`
 	Fields     []string `url:"fields,omitempty,comma"`
@@ -162,8 +216,8 @@ type OrganizationSearchParams struct {
 }
 
 type OrganizationSearchResult struct {
-	ResultScore float64
-	Item        Organization
+	ResultScore float64      `json:"result_score"`
+	Item        Organization `json:"item"`
 }
 
 type OrganizationsSearchResponse struct {
@@ -254,7 +308,7 @@ func (s *OrganizationsService) Update(ctx context.Context, id int, opt *Organiza
 func (s *OrganizationsService) Merge(ctx context.Context, id int, mergeWithID int) (*OrganizationResponse, *Response, error) {
 	uri := fmt.Sprintf("/organizations/%v/merge", id)
 	req, err := s.client.NewRequest(http.MethodPut, uri, nil, struct {
-		MergeWithID int `url:"merge_with_id"`
+		MergeWithID int `json:"merge_with_id"`
 	}{
 		mergeWithID,
 	})
@@ -0,0 +1,158 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+)
+
+// Paginator iterates over the records of a list endpoint, requesting the
+// next page when the records of the current one were read:
+//
+//	paginator := client.NewPaginator("/deals", &pipedrive.DealsListOptions{FilterID: 1})
+//
+//	for paginator.Next(ctx) {
+//		var deal pipedrive.Deal
+//
+//		if err := paginator.Decode(&deal); err != nil {
+//			return err
+//		}
+//	}
+//
+//	if err := paginator.Err(); err != nil {
+//		return err
+//	}
+//
+// Endpoints that are not paginated are read in a single page. Iteration
+// stops at an empty page, and fails if a page does not advance the start of
+// the next one, rather than reading the same records again.
+type Paginator struct {
+	client *Client
+	path   string
+	query  url.Values
+
+	page    []json.RawMessage
+	index   int
+	started bool
+	more    bool
+	start   int
+	next    int
+	err     error
+}
+
+// NewPaginator returns a paginator over the records listed by the v1
+// endpoint at path, such as "/deals". The query parameters are taken from
+// opt, a struct with url tags such as DealsListOptions, or url.Values; its
+// start and limit parameters are managed by the paginator, the limit
+// defaulting to 500.
+func (c *Client) NewPaginator(path string, opt interface{}) *Paginator {
+	p := &Paginator{
+		client: c,
+		path:   path,
+		query:  url.Values{},
+	}
+
+	switch opt := opt.(type) {
+	case nil:
+	case url.Values:
+		for key, value := range opt {
+			p.query[key] = value
+		}
+	default:
+		p.query, p.err = query.Values(opt)
+	}
+
+	if p.err == nil {
+		p.query.Del("start")
+
+		if p.query.Get("limit") == "" {
+			p.query.Set("limit", "500")
+		}
+	}
+
+	return p
+}
+
+// Next advances to the next record, requesting the next page if needed.
+// It returns false when there are no more records or a request failed,
+// see Err.
+func (p *Paginator) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+
+	for p.index >= len(p.page) {
+		if p.started && !p.more {
+			return false
+		}
+
+		if err := p.fetch(ctx); err != nil {
+			p.err = err
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Paginator) fetch(ctx context.Context) error {
+	if p.started && p.next <= p.start {
+		return fmt.Errorf("pagination of %s did not advance past start %d", p.path, p.start)
+	}
+
+	if p.next > 0 {
+		p.query.Set("start", strconv.Itoa(p.next))
+	}
+
+	req, err := p.client.NewRequest(http.MethodGet, p.path, p.query, nil)
+
+	if err != nil {
+		return err
+	}
+
+	var record struct {
+		Data           []json.RawMessage `json:"data"`
+		AdditionalData AdditionalData    `json:"additional_data"`
+	}
+
+	if _, err := p.client.Do(ctx, req, &record); err != nil {
+		return err
+	}
+
+	pagination := record.AdditionalData.Pagination
+
+	p.page = record.Data
+	p.index = 0
+	p.started = true
+	p.start = p.next
+	p.more = pagination.MoreItemsInCollection && len(record.Data) > 0
+	p.next = pagination.NextStart
+
+	return nil
+}
+
+// Raw returns the current record as returned by the API.
+func (p *Paginator) Raw() json.RawMessage {
+	if p.index >= len(p.page) {
+		return nil
+	}
+
+	return p.page[p.index]
+}
+
+// Decode decodes the current record into v, such as a *Deal.
+func (p *Paginator) Decode(v interface{}) error {
+	return json.Unmarshal(p.Raw(), v)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Paginator) Err() error {
+	return p.err
+}
@@ -0,0 +1,62 @@
+package pipedrive
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPaginator_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages map[string]string
+		count int
+		fails bool
+	}{
+		{
+			name: "empty page",
+			pages: map[string]string{
+				"":  `{"success": true, "data": [{"id": 1}], "additional_data": {"pagination": {"more_items_in_collection": true, "next_start": 1}}}`,
+				"1": `{"success": true, "data": [], "additional_data": {"pagination": {"more_items_in_collection": true, "next_start": 2}}}`,
+			},
+			count: 1,
+		},
+		{
+			name: "start not advancing",
+			pages: map[string]string{
+				"": `{"success": true, "data": [{"id": 1}], "additional_data": {"pagination": {"more_items_in_collection": true, "next_start": 0}}}`,
+			},
+			count: 1,
+			fails: true,
+		},
+	}
+
+	for _, test := range tests {
+		requests := 0
+
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			page, ok := test.pages[r.URL.Query().Get("start")]
+
+			if !ok || requests > len(test.pages) {
+				t.Errorf("%s: got unexpected request %s", test.name, r.URL)
+				http.NotFound(w, r)
+				return
+			}
+
+			w.Write([]byte(page))
+		}))
+
+		paginator := client.NewPaginator("/deals", nil)
+		count := 0
+
+		for paginator.Next(context.Background()) {
+			count++
+		}
+
+		if count != test.count || (paginator.Err() != nil) != test.fails {
+			t.Errorf("%s: got %d records and error %v", test.name, count, paginator.Err())
+		}
+	}
+}
@@ -13,46 +13,13 @@ import (
 type PersonFieldsService service
 
 // PersonField represents a Pipedrive person field.
-type PersonField struct {
-	ID                 int         `json:"id"`
-	Key                string      `json:"key"`
-	Name               string      `json:"name"`
-	OrderNr            int         `json:"order_nr"`
-	PicklistData       interface{} `json:"picklist_data,omitempty"`
-	FieldType          FieldType   `json:"field_type"`
-	AddTime            string      `json:"add_time"`
-	UpdateTime         string      `json:"update_time"`
-	ActiveFlag         bool        `json:"active_flag"`
-	EditFlag           bool        `json:"edit_flag"`
-	IndexVisibleFlag   bool        `json:"index_visible_flag"`
-	DetailsVisibleFlag bool        `json:"details_visible_flag"`
-	AddVisibleFlag     bool        `json:"add_visible_flag"`
-	ImportantFlag      bool        `json:"important_flag"`
-	BulkEditAllowed    bool        `json:"bulk_edit_allowed"`
-	SearchableFlag     bool        `json:"searchable_flag"`
-	FilteringAllowed   bool        `json:"filtering_allowed"`
-	SortableFlag       bool        `json:"sortable_flag"`
-	UseField           string      `json:"use_field,omitempty"`
-	Link               string      `json:"link,omitempty"`
-	MandatoryFlag      bool        `json:"mandatory_flag"`
-	DisplayField       string      `json:"display_field,omitempty"`
-	Autocomplete       string      `json:"autocomplete,omitempty"`
-	Options            []Option    `json:"options,omitempty"`
-}
+type PersonField = EntityField
 
 // PersonFieldsResponse represents multiple person fields response.
-type PersonFieldsResponse struct {
-	Success        bool           `json:"success"`
-	Data           []PersonField  `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type PersonFieldsResponse = EntityFieldsResponse
 
 // PersonFieldResponse represents single person field response.
-type PersonFieldResponse struct {
-	Success        bool           `json:"success"`
-	Data           PersonField    `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type PersonFieldResponse = EntityFieldResponse
 
 // List all person fields.
 //
@@ -100,22 +67,34 @@ func (s *PersonFieldsService) GetByID(ctx context.Context, id int) (*PersonField
 // PersonFieldCreateOptions specifices the optional parameters to the
 // PersonFieldsService.Create method.
 type PersonFieldCreateOptions struct {
-	Name      string    `url:"name"`
-	FieldType FieldType `url:"field_type"`
-	Options   string    `url:"options"`
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+
+	// Options are the labels of the options of enum and set fields.
+	Options []string `json:"-"`
 }
 
 // Create a person field.
 //
-// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/ProductFields/post_productFields
-func (s *PersonFieldsService) Create(ctx context.Context, opt *PersonFieldCreateOptions) (*ProductFieldResponse, *Response, error) {
-	req, err := s.client.NewRequest(http.MethodPost, "/personFields", nil, opt)
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/PersonFields/post_personFields
+func (s *PersonFieldsService) Create(ctx context.Context, opt *PersonFieldCreateOptions) (*PersonFieldResponse, *Response, error) {
+	if opt == nil {
+		opt = &PersonFieldCreateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
 
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var record *ProductFieldResponse
+	req, err := s.client.NewRequest(http.MethodPost, "/personFields", nil, body)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *PersonFieldResponse
 
 	resp, err := s.client.Do(ctx, req, &record)
 
@@ -129,16 +108,30 @@ func (s *PersonFieldsService) Create(ctx context.Context, opt *PersonFieldCreate
 // PersonFieldUpdateOptions specifices the optional parameters to the
 // PersonFieldsService.Update method.
 type PersonFieldUpdateOptions struct {
-	Name    string `url:"name"`
-	Options string `url:"options"`
+	Name string `json:"name"`
+
+	// Options replace the options of enum and set fields. Existing options
+	// must be given with their ID, options left out being deleted; new
+	// options only need a label.
+	Options []Option `json:"-"`
 }
 
 // Update a person field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/PersonFields/put_personFields_id
 func (s *PersonFieldsService) Update(ctx context.Context, id int, opt *PersonFieldUpdateOptions) (*PersonFieldResponse, *Response, error) {
+	if opt == nil {
+		opt = &PersonFieldUpdateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	uri := fmt.Sprintf("/personFields/%v", id)
-	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -183,3 +176,55 @@ func (s *PersonFieldsService) Delete(ctx context.Context, id int) (*Response, er
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// AddOption adds an option labeled label to an enum or set person field,
+// keeping the IDs of the other options.
+func (s *PersonFieldsService) AddOption(ctx context.Context, id int, label string) (*FieldDefinition, error) {
+	return s.client.addFieldOption(ctx, OBJECT_PERSON, id, label)
+}
+
+// RenameOption relabels an option of an enum or set person field, keeping
+// its ID and the values of the records using it.
+func (s *PersonFieldsService) RenameOption(ctx context.Context, id int, optionID int, label string) (*FieldDefinition, error) {
+	return s.client.renameFieldOption(ctx, OBJECT_PERSON, id, optionID, label)
+}
+
+// ReorderOptions orders the options of an enum or set person field as
+// optionIDs, which must list every option.
+func (s *PersonFieldsService) ReorderOptions(ctx context.Context, id int, optionIDs []int) (*FieldDefinition, error) {
+	return s.client.reorderFieldOptions(ctx, OBJECT_PERSON, id, optionIDs)
+}
+
+// RemoveOption removes an option of an enum or set person field. If
+// migrateTo is not 0, the persons using the option are first moved to the
+// option with that ID, and the option is kept if any cannot be moved. The
+// last option of a field cannot be removed.
+func (s *PersonFieldsService) RemoveOption(ctx context.Context, id int, optionID int, migrateTo int) (*FieldOptionRemoval, error) {
+	return s.client.removeFieldOption(ctx, OBJECT_PERSON, id, optionID, migrateTo)
+}
+
+// ListFields returns all person fields. It implements FieldsAPI.
+func (s *PersonFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_PERSON)
+}
+
+// GetField returns the person field with the given ID. It implements
+// FieldsAPI.
+func (s *PersonFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_PERSON, id)
+}
+
+// CreateField creates a person field. It implements FieldsAPI.
+func (s *PersonFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_PERSON, opt)
+}
+
+// UpdateField updates a person field. It implements FieldsAPI.
+func (s *PersonFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_PERSON, id, opt)
+}
+
+// DeleteField deletes a person field. It implements FieldsAPI.
+func (s *PersonFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_PERSON, id)
+}
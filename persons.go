@@ -189,8 +189,8 @@ type PersonSearchParams struct {
 }
 
 type PersonSearchResult struct {
-	ResultScore float64
-	Item        Person
+	ResultScore float64 `json:"result_score"`
+	Item        Person  `json:"item"`
 }
 
 type PersonsSearchResponse struct {
@@ -221,6 +221,28 @@ func (s *PersonsService) Search(ctx context.Context, searchParams PersonSearchPa
 	return response, resp, nil
 }
 
+// ListMailMessages lists mail messages associated with a person.
+//
+// Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/Persons#getPersonMailMessages
+func (s *PersonsService) ListMailMessages(ctx context.Context, id int, opt *PaginationOptions) (*MailMessageItemsResponse, *Response, error) {
+	uri := fmt.Sprintf("/persons/%v/mailMessages", id)
+	req, err := s.client.NewRequest(http.MethodGet, uri, opt, nil)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var record *MailMessageItemsResponse
+
+	resp, err := s.client.Do(ctx, req, &record)
+
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return record, resp, nil
+}
+
 // AddFollower adds a follower to person.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/Persons/post_persons_id_followers
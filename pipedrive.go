@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -23,6 +25,9 @@ const (
 
 	libraryVersion = "1"
 
+	// Path prefix of the v2 API, which hosts endpoints that have no v1 counterpart.
+	apiV2Prefix = "api/v2"
+
 	hostProtocol = "https"
 
 	// The amount of requests current API token can perform for the 10 seconds window.
@@ -77,6 +82,13 @@ type Client struct {
 	DealFields        *DealFieldsService
 	Persons           *PersonsService
 	Organizations     *OrganizationsService
+	Leads             *LeadsService
+	LeadLabels        *LeadLabelsService
+	LeadSources       *LeadSourcesService
+	Mailbox           *MailboxService
+	CallLogs          *CallLogsService
+
+	OrganizationRelationships *OrganizationRelationshipsService
 }
 
 type service struct {
@@ -126,11 +138,20 @@ func parseRateFromResponse(r *http.Response) Rate {
 }
 
 func (c *Client) NewRequest(method, url string, opt interface{}, body interface{}) (*http.Request, error) {
+	return c.newRequest(method, "v"+libraryVersion, url, opt, body)
+}
+
+// newRequestV2 creates a request against the v2 API.
+func (c *Client) newRequestV2(method, url string, opt interface{}, body interface{}) (*http.Request, error) {
+	return c.newRequest(method, apiV2Prefix, url, opt, body)
+}
+
+func (c *Client) newRequest(method, prefix, url string, opt interface{}, body interface{}) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
 
-	u, err := c.createRequestUrl(url, opt)
+	u, err := c.createRequestUrl(prefix, url, opt)
 
 	if err != nil {
 		return nil, err
@@ -162,6 +183,142 @@ func (c *Client) NewRequest(method, url string, opt interface{}, body interface{
 	return request, nil
 }
 
+// MultipartFile describes a file sent as part of a multipart/form-data request.
+type MultipartFile struct {
+	// Name of the form field holding the file.
+	FieldName string
+
+	FileName    string
+	ContentType string
+	Reader      io.Reader
+
+	// Size of the file in bytes. When it is greater than zero the request is
+	// sent with a Content-Length header, otherwise it is sent chunked.
+	Size int64
+}
+
+// switchWriter writes to whichever writer it currently points to.
+type switchWriter struct {
+	io.Writer
+}
+
+// NewMultipartRequest creates an API request whose body is multipart/form-data
+// made of the given form fields followed by file. The file contents are
+// streamed from file.Reader while the request is sent, never buffered. As with
+// any request body, file.Reader is closed once sent if it is an io.Closer.
+func (c *Client) NewMultipartRequest(method, url string, form map[string]string, file *MultipartFile) (*http.Request, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+
+	u, err := c.createRequestUrl("v"+libraryVersion, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	head, tail := new(bytes.Buffer), new(bytes.Buffer)
+	w := &switchWriter{head}
+	writer := multipart.NewWriter(w)
+
+	for name, value := range form {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	contentType := file.ContentType
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(file.FieldName), quoteEscaper.Replace(file.FileName)))
+	header.Set("Content-Type", contentType)
+
+	if _, err := writer.CreatePart(header); err != nil {
+		return nil, err
+	}
+
+	// Closing the writer only emits the final boundary, which is sent after
+	// the file contents.
+	w.Writer = tail
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	body := &multipartBody{Reader: io.MultiReader(head, file.Reader, tail)}
+
+	if closer, ok := file.Reader.(io.Closer); ok {
+		body.closer = closer
+	}
+
+	request, err := http.NewRequest(method, u, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if file.Size > 0 {
+		request.ContentLength = int64(head.Len()) + file.Size + int64(tail.Len())
+	}
+
+	return request, nil
+}
+
+// multipartBody closes the file reader, if it is a Closer, when the request
+// body is closed. This unblocks a pending read when the request is canceled.
+type multipartBody struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (b *multipartBody) Close() error {
+	if b.closer != nil {
+		return b.closer.Close()
+	}
+
+	return nil
+}
+
+// closeOnDone closes r, if it is a Closer, when ctx is done before the
+// returned stop function is called. A read blocked on r when an upload is
+// canceled would otherwise keep the request from returning.
+func closeOnDone(ctx context.Context, r io.Reader) (stop func()) {
+	closer, ok := r.(io.Closer)
+
+	if !ok {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			closer.Close()
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// Rate returns the rate limit of the last response received.
+func (c *Client) Rate() Rate {
+	c.rateMutex.Lock()
+	defer c.rateMutex.Unlock()
+
+	return c.currentRate
+}
+
 func (c *Client) checkRateLimitBeforeDo(req *http.Request) *RateLimitError {
 	c.rateMutex.Lock()
 	rate := c.currentRate
@@ -210,12 +367,15 @@ func (c *Client) checkResponse(r *http.Response) error {
 	}
 }
 
-// Do sends an API request and returns the API response. T
+// Do sends an API request and returns the API response. The API response is
+// JSON decoded and stored in the value pointed to by v, or copied to v as-is
+// if v implements the io.Writer interface. The response body is discarded if
+// v is nil.
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
 func (c *Client) Do(ctx context.Context, request *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(request)
+	resp, err := c.client.Do(request.WithContext(ctx))
 
 	if err != nil {
 		select {
@@ -244,6 +404,24 @@ func (c *Client) Do(ctx context.Context, request *http.Request, v interface{}) (
 		return response, err
 	}
 
+	if v == nil {
+		return response, nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		// A server ignoring the Range header of the request sends the
+		// whole body, skip up to where the requested range starts.
+		if start := rangeStart(request); start > 0 && resp.StatusCode == http.StatusOK {
+			if _, err = io.CopyN(ioutil.Discard, resp.Body, start); err != nil {
+				return response, err
+			}
+		}
+
+		_, err = io.Copy(w, resp.Body)
+
+		return response, err
+	}
+
 	err = json.NewDecoder(resp.Body).Decode(v)
 
 	if err == io.EOF {
@@ -253,8 +431,20 @@ func (c *Client) Do(ctx context.Context, request *http.Request, v interface{}) (
 	return response, err
 }
 
-func (c *Client) createRequestUrl(path string, opt interface{}) (string, error) {
-	uri, err := c.BaseURL.Parse(hostProtocol + "://" + defaultBaseUrl + "v" + libraryVersion)
+// rangeStart returns the first byte requested by an open-ended Range header
+// of the form "bytes=N-", or 0.
+func rangeStart(request *http.Request) int64 {
+	var start int64
+
+	if _, err := fmt.Sscanf(request.Header.Get("Range"), "bytes=%d-", &start); err != nil {
+		return 0
+	}
+
+	return start
+}
+
+func (c *Client) createRequestUrl(prefix, path string, opt interface{}) (string, error) {
+	uri, err := c.BaseURL.Parse(hostProtocol + "://" + defaultBaseUrl + prefix)
 
 	if err != nil {
 		return path, err
@@ -273,10 +463,20 @@ func (c *Client) createRequestUrl(path string, opt interface{}) (string, error)
 		return uri.String(), nil
 	}
 
-	qs, err := query.Values(opt)
+	var qs url.Values
 
-	if err != nil {
-		return path, err
+	if values, ok := opt.(url.Values); ok {
+		qs = url.Values{}
+
+		for key, value := range values {
+			qs[key] = value
+		}
+	} else {
+		qs, err = query.Values(opt)
+
+		if err != nil {
+			return path, err
+		}
 	}
 
 	qs.Add("api_token", c.apiKey)
@@ -337,6 +537,12 @@ func NewClient(options *Config) *Client {
 	c.DealFields = (*DealFieldsService)(&c.common)
 	c.Persons = (*PersonsService)(&c.common)
 	c.Organizations = (*OrganizationsService)(&c.common)
+	c.Leads = (*LeadsService)(&c.common)
+	c.LeadLabels = (*LeadLabelsService)(&c.common)
+	c.LeadSources = (*LeadSourcesService)(&c.common)
+	c.OrganizationRelationships = (*OrganizationRelationshipsService)(&c.common)
+	c.Mailbox = (*MailboxService)(&c.common)
+	c.CallLogs = (*CallLogsService)(&c.common)
 
 	return c
 }
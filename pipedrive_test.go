@@ -0,0 +1,33 @@
+package pipedrive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// rewriteTransport sends requests to a test server.
+type rewriteTransport struct {
+	url *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme = t.url.Scheme
+	r.URL.Host = t.url.Host
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestClient returns a client sending its requests to handler.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+
+	return NewClient(&Config{
+		APIKey: "token",
+		Client: &http.Client{Transport: rewriteTransport{u}},
+	})
+}
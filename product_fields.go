@@ -13,52 +13,13 @@ import (
 type ProductFieldsService service
 
 // ProductField represents a Pipedrive product field.
-type ProductField struct {
-	ID                 int         `json:"id"`
-	Key                string      `json:"key"`
-	Name               string      `json:"name"`
-	OrderNr            int         `json:"order_nr"`
-	PicklistData       interface{} `json:"picklist_data,omitempty"`
-	FieldType          string      `json:"field_type"`
-	AddTime            string      `json:"add_time"`
-	UpdateTime         string      `json:"update_time"`
-	ActiveFlag         bool        `json:"active_flag"`
-	EditFlag           bool        `json:"edit_flag"`
-	IndexVisibleFlag   bool        `json:"index_visible_flag"`
-	DetailsVisibleFlag bool        `json:"details_visible_flag"`
-	AddVisibleFlag     bool        `json:"add_visible_flag"`
-	ImportantFlag      bool        `json:"important_flag"`
-	BulkEditAllowed    bool        `json:"bulk_edit_allowed"`
-	SearchableFlag     bool        `json:"searchable_flag"`
-	FilteringAllowed   bool        `json:"filtering_allowed"`
-	SortableFlag       bool        `json:"sortable_flag"`
-	UseField           string      `json:"use_field,omitempty"`
-	Link               string      `json:"link,omitempty"`
-	MandatoryFlag      bool        `json:"mandatory_flag"`
-	DisplayField       string      `json:"display_field,omitempty"`
-	Options            []struct {
-		ID    bool   `json:"id"`
-		Label string `json:"label"`
-	} `json:"options,omitempty"`
-}
-
-func (p ProductField) String() string {
-	return Stringify(p)
-}
+type ProductField = EntityField
 
 // ProductFieldsResponse represents multiple product fields response.
-type ProductFieldsResponse struct {
-	Success        bool           `json:"success"`
-	Data           []ProductField `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type ProductFieldsResponse = EntityFieldsResponse
 
 // ProductFieldResponse represents single product field response.
-type ProductFieldResponse struct {
-	Success        bool           `json:"success"`
-	Data           ProductField   `json:"data"`
-	AdditionalData AdditionalData `json:"additional_data"`
-}
+type ProductFieldResponse = EntityFieldResponse
 
 // List returns all data about product fields.
 //
@@ -106,16 +67,28 @@ func (s *ProductFieldsService) GetByID(ctx context.Context, id int) (*ProductFie
 // ProductFieldCreateOptions specifices the optional parameters to the
 // ProductFieldsService.Create method.
 type ProductFieldCreateOptions struct {
-	Name      string    `url:"name"`
-	FieldType FieldType `url:"field_type"`
-	Options   string    `url:"options"`
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+
+	// Options are the labels of the options of enum and set fields.
+	Options []string `json:"-"`
 }
 
 // Create a new product field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/ProductFields/post_productFields
 func (s *ProductFieldsService) Create(ctx context.Context, opt *ProductFieldCreateOptions) (*ProductFieldResponse, *Response, error) {
-	req, err := s.client.NewRequest(http.MethodPost, "/productFields", nil, opt)
+	if opt == nil {
+		opt = &ProductFieldCreateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "/productFields", nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -135,16 +108,30 @@ func (s *ProductFieldsService) Create(ctx context.Context, opt *ProductFieldCrea
 // ProductFieldUpdateOptions specifices the optional parameters to the
 // ProductFieldsService.Update method.
 type ProductFieldUpdateOptions struct {
-	Name    string `url:"name"`
-	Options string `url:"options"`
+	Name string `json:"name"`
+
+	// Options replace the options of enum and set fields. Existing options
+	// must be given with their ID, options left out being deleted; new
+	// options only need a label.
+	Options []Option `json:"-"`
 }
 
 // Update a specific product field.
 //
 // Pipedrive API docs: https://developers.pipedrive.com/docs/api/v1/#!/ProductFields/put_productFields_id
 func (s *ProductFieldsService) Update(ctx context.Context, id int, opt *ProductFieldUpdateOptions) (*ProductFieldResponse, *Response, error) {
+	if opt == nil {
+		opt = &ProductFieldUpdateOptions{}
+	}
+
+	body, err := fieldBody(opt, opt.Options)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
 	uri := fmt.Sprintf("/productFields/%v", id)
-	req, err := s.client.NewRequest(http.MethodPut, uri, nil, opt)
+	req, err := s.client.NewRequest(http.MethodPut, uri, nil, body)
 
 	if err != nil {
 		return nil, nil, err
@@ -189,3 +176,55 @@ func (s *ProductFieldsService) Delete(ctx context.Context, id int) (*Response, e
 
 	return s.client.Do(ctx, req, nil)
 }
+
+// AddOption adds an option labeled label to an enum or set product field,
+// keeping the IDs of the other options.
+func (s *ProductFieldsService) AddOption(ctx context.Context, id int, label string) (*FieldDefinition, error) {
+	return s.client.addFieldOption(ctx, OBJECT_PRODUCT, id, label)
+}
+
+// RenameOption relabels an option of an enum or set product field, keeping
+// its ID and the values of the records using it.
+func (s *ProductFieldsService) RenameOption(ctx context.Context, id int, optionID int, label string) (*FieldDefinition, error) {
+	return s.client.renameFieldOption(ctx, OBJECT_PRODUCT, id, optionID, label)
+}
+
+// ReorderOptions orders the options of an enum or set product field as
+// optionIDs, which must list every option.
+func (s *ProductFieldsService) ReorderOptions(ctx context.Context, id int, optionIDs []int) (*FieldDefinition, error) {
+	return s.client.reorderFieldOptions(ctx, OBJECT_PRODUCT, id, optionIDs)
+}
+
+// RemoveOption removes an option of an enum or set product field. If
+// migrateTo is not 0, the products using the option are first moved to the
+// option with that ID, and the option is kept if any cannot be moved. The
+// last option of a field cannot be removed.
+func (s *ProductFieldsService) RemoveOption(ctx context.Context, id int, optionID int, migrateTo int) (*FieldOptionRemoval, error) {
+	return s.client.removeFieldOption(ctx, OBJECT_PRODUCT, id, optionID, migrateTo)
+}
+
+// ListFields returns all product fields. It implements FieldsAPI.
+func (s *ProductFieldsService) ListFields(ctx context.Context) ([]EntityField, error) {
+	return s.client.listFields(ctx, OBJECT_PRODUCT)
+}
+
+// GetField returns the product field with the given ID. It implements
+// FieldsAPI.
+func (s *ProductFieldsService) GetField(ctx context.Context, id int) (*EntityField, error) {
+	return s.client.getField(ctx, OBJECT_PRODUCT, id)
+}
+
+// CreateField creates a product field. It implements FieldsAPI.
+func (s *ProductFieldsService) CreateField(ctx context.Context, opt *FieldCreateOptions) (*EntityField, error) {
+	return s.client.createField(ctx, OBJECT_PRODUCT, opt)
+}
+
+// UpdateField updates a product field. It implements FieldsAPI.
+func (s *ProductFieldsService) UpdateField(ctx context.Context, id int, opt *FieldUpdateOptions) (*EntityField, error) {
+	return s.client.updateField(ctx, OBJECT_PRODUCT, id, opt)
+}
+
+// DeleteField deletes a product field. It implements FieldsAPI.
+func (s *ProductFieldsService) DeleteField(ctx context.Context, id int) error {
+	return s.client.deleteField(ctx, OBJECT_PRODUCT, id)
+}
@@ -2,6 +2,8 @@ package pipedrive
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -39,11 +41,121 @@ func (rrd RecentRecordDetails) String() string {
 	return Stringify(rrd)
 }
 
+// Type of recent items.
+type RecentItem string
+
+const (
+	RECENT_ITEM_ACTIVITY      RecentItem = "activity"
+	RECENT_ITEM_ACTIVITY_TYPE RecentItem = "activityType"
+	RECENT_ITEM_DEAL          RecentItem = "deal"
+	RECENT_ITEM_FILE          RecentItem = "file"
+	RECENT_ITEM_FILTER        RecentItem = "filter"
+	RECENT_ITEM_NOTE          RecentItem = "note"
+	RECENT_ITEM_PERSON        RecentItem = "person"
+	RECENT_ITEM_ORGANIZATION  RecentItem = "organization"
+	RECENT_ITEM_PIPELINE      RecentItem = "pipeline"
+	RECENT_ITEM_PRODUCT       RecentItem = "product"
+	RECENT_ITEM_STAGE         RecentItem = "stage"
+	RECENT_ITEM_USER          RecentItem = "user"
+)
+
+// recentItemTypes returns an empty value of the type each item decodes
+// into.
+var recentItemTypes = map[RecentItem]func() interface{}{
+	RECENT_ITEM_ACTIVITY:      func() interface{} { return &Activity{} },
+	RECENT_ITEM_ACTIVITY_TYPE: func() interface{} { return &ActivityType{} },
+	RECENT_ITEM_DEAL:          func() interface{} { return &Deal{} },
+	RECENT_ITEM_FILE:          func() interface{} { return &File{} },
+	RECENT_ITEM_FILTER:        func() interface{} { return &Filter{} },
+	RECENT_ITEM_NOTE:          func() interface{} { return &Note{} },
+	RECENT_ITEM_PERSON:        func() interface{} { return &Person{} },
+	RECENT_ITEM_ORGANIZATION:  func() interface{} { return &Organization{} },
+	RECENT_ITEM_PIPELINE:      func() interface{} { return &Pipeline{} },
+	RECENT_ITEM_PRODUCT:       func() interface{} { return &Product{} },
+	RECENT_ITEM_STAGE:         func() interface{} { return &Stage{} },
+	RECENT_ITEM_USER:          func() interface{} { return &User{} },
+}
+
 // RecentRecord represents a Pipedrive recent record.
+//
+// Data is only set for users, whose data is a list. RawData holds the data
+// of any item, which Decode converts to its entity type.
 type RecentRecord struct {
-	Item string                `json:"item"`
-	ID   int                   `json:"id"`
-	Data []RecentRecordDetails `json:"data"`
+	Item    string                `json:"item"`
+	ID      int                   `json:"id"`
+	Data    []RecentRecordDetails `json:"data"`
+	RawData json.RawMessage       `json:"-"`
+}
+
+func (rr RecentRecord) String() string {
+	return Stringify(rr)
+}
+
+func (rr *RecentRecord) UnmarshalJSON(b []byte) error {
+	var record struct {
+		Item string          `json:"item"`
+		ID   int             `json:"id"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(b, &record); err != nil {
+		return err
+	}
+
+	*rr = RecentRecord{
+		Item:    record.Item,
+		ID:      record.ID,
+		RawData: record.Data,
+	}
+
+	// The data of users is a list, the data of other items an object.
+	if len(record.Data) > 0 && record.Data[0] == '[' {
+		if err := json.Unmarshal(record.Data, &rr.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode returns the data of the record as its entity type, such as *Deal
+// for a deal or *User for a user. It returns nil if the record has no
+// data.
+func (rr RecentRecord) Decode() (interface{}, error) {
+	newRecord, ok := recentItemTypes[RecentItem(rr.Item)]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown recent item %q", rr.Item)
+	}
+
+	data := rr.RawData
+
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	// Users are listed, one per record.
+	if data[0] == '[' {
+		var list []json.RawMessage
+
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+
+		if len(list) == 0 {
+			return nil, nil
+		}
+
+		data = list[0]
+	}
+
+	record := newRecord()
+
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
 }
 
 // RecentsResponse represents multiple recents response.
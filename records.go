@@ -0,0 +1,110 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// recordObject returns the object type of a record, such as OBJECT_DEAL
+// for a Deal or *Deal.
+func recordObject(record interface{}) (EventObject, bool) {
+	switch record.(type) {
+	case Deal, *Deal:
+		return OBJECT_DEAL, true
+	case Person, *Person:
+		return OBJECT_PERSON, true
+	case Organization, *Organization:
+		return OBJECT_ORGANIZATION, true
+	case Activity, *Activity:
+		return OBJECT_ACTIVITY, true
+	case ActivityType, *ActivityType:
+		return OBJECT_ACTIVTIY_TYPE, true
+	case Note, *Note:
+		return OBJECT_NOTE, true
+	case Product, *Product:
+		return OBJECT_PRODUCT, true
+	case Stage, *Stage:
+		return OBJECT_STAGE, true
+	case Pipeline, *Pipeline:
+		return OBJECT_PIPELINE, true
+	case User, *User:
+		return OBJECT_USER, true
+	}
+
+	return "", false
+}
+
+// recordValues returns the values of a record keyed by field key, custom
+// fields included. The record is a struct, a pointer to one, a map or raw
+// JSON.
+func recordValues(record interface{}) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	switch r := record.(type) {
+	case nil:
+		return values, nil
+	case map[string]interface{}:
+		for key, value := range r {
+			values[key] = value
+		}
+		return values, nil
+	case json.RawMessage:
+		if len(r) == 0 {
+			return values, nil
+		}
+		return values, json.Unmarshal(r, &values)
+	}
+
+	v := reflect.ValueOf(record)
+
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return values, nil
+	}
+
+	b, err := json.Marshal(record)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+
+	if custom := reflect.Indirect(v).FieldByName("CustomFields"); custom.IsValid() {
+		for key, value := range custom.Interface().(map[string]interface{}) {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+// getRecord returns the record of object with the given ID as returned by
+// the API.
+func (c *Client) getRecord(ctx context.Context, object EventObject, id int) (json.RawMessage, error) {
+	path, err := collectionPath(object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d", path, id), nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var record struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if _, err := c.Do(ctx, req, &record); err != nil {
+		return nil, err
+	}
+
+	return record.Data, nil
+}
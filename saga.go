@@ -0,0 +1,331 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SagaStep creates one record of a saga, such as the organization, person
+// and deal of a new customer.
+type SagaStep struct {
+	// Name identifies the step, for other steps to refer to the record it
+	// creates.
+	Name string
+
+	Object EventObject
+
+	// Data is the body of the create, such as a *PersonCreateOptions or a
+	// map.
+	Data interface{}
+
+	// Refs maps keys of the body to the names of the steps whose record
+	// ID they take, such as {"org_id": "org"}. The steps referred to run
+	// first.
+	Refs map[string]string
+}
+
+// Status of sagas.
+type SagaStatus string
+
+const (
+	// Every step created its record.
+	SAGA_COMPLETED SagaStatus = "completed"
+	// A step failed and the records created before were deleted.
+	SAGA_COMPENSATED SagaStatus = "compensated"
+	// A step failed and some of the records created before could not be
+	// deleted. They are the orphans of the result.
+	SAGA_COMPENSATION_FAILED SagaStatus = "compensation_failed"
+)
+
+// Status of saga steps.
+type SagaStepStatus string
+
+const (
+	SAGA_STEP_CREATED SagaStepStatus = "created"
+	SAGA_STEP_FAILED  SagaStepStatus = "failed"
+	// The step did not run, a step before it having failed.
+	SAGA_STEP_SKIPPED SagaStepStatus = "skipped"
+	// The record was created, then deleted after a step failed.
+	SAGA_STEP_COMPENSATED SagaStepStatus = "compensated"
+	// The record was created, and could not be deleted after a step
+	// failed.
+	SAGA_STEP_ORPHANED SagaStepStatus = "orphaned"
+)
+
+// SagaStepResult reports the outcome of a saga step.
+type SagaStepResult struct {
+	Name   string         `json:"name"`
+	Object EventObject    `json:"object"`
+	Status SagaStepStatus `json:"status"`
+
+	// ID of the record created, kept when deleted for reference.
+	ID int `json:"id,omitempty"`
+
+	// Error is the error of failed steps, or the error deleting the
+	// record of orphaned steps.
+	Error string `json:"error,omitempty"`
+
+	// Err is the error behind Error. It is not part of reports.
+	Err error `json:"-"`
+}
+
+func (r SagaStepResult) String() string {
+	return Stringify(r)
+}
+
+// SagaResult reports the outcome of a saga.
+type SagaResult struct {
+	Status SagaStatus `json:"status"`
+
+	// Steps lists the results of the steps in the order they ran, steps
+	// skipped last.
+	Steps []SagaStepResult `json:"steps"`
+
+	// FailedStep is the name of the step which failed, if any.
+	FailedStep string `json:"failed_step,omitempty"`
+}
+
+func (r SagaResult) String() string {
+	return Stringify(r)
+}
+
+// IDs returns the IDs of the records created and kept, by step name.
+func (r SagaResult) IDs() map[string]int {
+	ids := map[string]int{}
+
+	for _, step := range r.Steps {
+		if step.Status == SAGA_STEP_CREATED {
+			ids[step.Name] = step.ID
+		}
+	}
+
+	return ids
+}
+
+// Orphans returns the steps whose record could not be deleted.
+func (r SagaResult) Orphans() []SagaStepResult {
+	var orphans []SagaStepResult
+
+	for _, step := range r.Steps {
+		if step.Status == SAGA_STEP_ORPHANED {
+			orphans = append(orphans, step)
+		}
+	}
+
+	return orphans
+}
+
+// RunSaga creates the records of steps one at a time, each after the steps
+// it refers to, setting the IDs of the records they created in its body.
+// Creates are only retried when rate limited, as by a BatchRunner. A create
+// failing with a server or network error, or cancelled with ctx while in
+// flight, may nonetheless have created a record: the saga does not know of
+// it and cannot delete it, so the caller should look for it if the failed
+// step reports such an error.
+//
+// If a step fails, the records created are deleted in the reverse order of
+// their creation, and a *SagaError is returned with the result. Every
+// record is tried even if deleting another failed; those which could not be
+// deleted are reported as orphaned, for the caller to clean up. Deletes are
+// sent even if ctx is done, the records being orphaned otherwise, each
+// within a minute, waits for the rate limit included. Deleted
+// deals, persons and organizations remain restorable in Pipedrive for 30
+// days.
+//
+// An error without result is returned if steps do not form a valid graph,
+// in which case nothing is created.
+func (c *Client) RunSaga(ctx context.Context, steps []SagaStep) (*SagaResult, error) {
+	order, err := sagaOrder(steps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SagaResult{Status: SAGA_COMPLETED}
+	runner := c.NewBatchRunner()
+	ids := map[string]int{}
+
+	var failure *SagaError
+
+	for i, step := range order {
+		stepResult := SagaStepResult{Name: step.Name, Object: step.Object}
+
+		body, err := recordValues(step.Data)
+
+		if err == nil {
+			for key, name := range step.Refs {
+				body[key] = ids[name]
+			}
+
+			batchResult, done := runner.run(ctx, BatchOperation{
+				Key:    step.Name,
+				Type:   BATCH_CREATE,
+				Object: step.Object,
+				Data:   body,
+			})
+
+			switch {
+			case !done:
+				err = ctx.Err()
+			case batchResult.Status != BATCH_OK:
+				err = batchResult.Err
+			default:
+				stepResult.ID = batchResult.ID
+			}
+		}
+
+		if err == nil {
+			stepResult.Status = SAGA_STEP_CREATED
+			ids[step.Name] = stepResult.ID
+			result.Steps = append(result.Steps, stepResult)
+
+			continue
+		}
+
+		stepResult.Status = SAGA_STEP_FAILED
+		stepResult.Error = err.Error()
+		stepResult.Err = err
+		result.Steps = append(result.Steps, stepResult)
+		result.FailedStep = step.Name
+
+		for _, skipped := range order[i+1:] {
+			result.Steps = append(result.Steps, SagaStepResult{
+				Name:   skipped.Name,
+				Object: skipped.Object,
+				Status: SAGA_STEP_SKIPPED,
+			})
+		}
+
+		failure = &SagaError{Step: step.Name, Object: step.Object, Err: err}
+
+		break
+	}
+
+	if failure == nil {
+		return result, nil
+	}
+
+	result.Status = SAGA_COMPENSATED
+
+	for i := len(result.Steps) - 1; i >= 0; i-- {
+		step := &result.Steps[i]
+
+		if step.Status != SAGA_STEP_CREATED {
+			continue
+		}
+
+		compensation, cancel := context.WithTimeout(detachedContext{ctx}, sagaCompensationTimeout)
+
+		batchResult, _ := runner.run(compensation, BatchOperation{
+			Key:    step.Name,
+			Type:   BATCH_DELETE,
+			Object: step.Object,
+			ID:     step.ID,
+		})
+
+		cancel()
+
+		if batchResult.Status != BATCH_OK && batchResult.Status != BATCH_SKIPPED {
+			step.Status = SAGA_STEP_ORPHANED
+			step.Error = batchResult.Error
+			step.Err = batchResult.Err
+			result.Status = SAGA_COMPENSATION_FAILED
+			failure.Orphans = append(failure.Orphans, *step)
+
+			continue
+		}
+
+		step.Status = SAGA_STEP_COMPENSATED
+	}
+
+	return result, failure
+}
+
+// sagaOrder returns steps ordered for each to follow the steps it refers
+// to, keeping the order given otherwise.
+func sagaOrder(steps []SagaStep) ([]SagaStep, error) {
+	byName := map[string]int{}
+
+	for i, step := range steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("saga step %d has no name", i)
+		}
+
+		if _, ok := byName[step.Name]; ok {
+			return nil, fmt.Errorf("saga step %q is defined twice", step.Name)
+		}
+
+		if _, err := collectionPath(step.Object); err != nil {
+			return nil, fmt.Errorf("saga step %q: %w", step.Name, err)
+		}
+
+		byName[step.Name] = i
+	}
+
+	for _, step := range steps {
+		for key, name := range step.Refs {
+			if _, ok := byName[name]; !ok {
+				return nil, fmt.Errorf("saga step %q: %s refers to unknown step %q", step.Name, key, name)
+			}
+		}
+	}
+
+	order := make([]SagaStep, 0, len(steps))
+	placed := map[string]bool{}
+
+	for len(order) < len(steps) {
+		progress := false
+
+		for _, step := range steps {
+			if placed[step.Name] {
+				continue
+			}
+
+			ready := true
+
+			for _, name := range step.Refs {
+				if !placed[name] {
+					ready = false
+				}
+			}
+
+			if ready {
+				order = append(order, step)
+				placed[step.Name] = true
+				progress = true
+			}
+		}
+
+		if !progress {
+			return nil, fmt.Errorf("saga steps refer to each other in a cycle")
+		}
+	}
+
+	return order, nil
+}
+
+// sagaCompensationTimeout bounds each delete compensating a failed saga.
+var sagaCompensationTimeout = time.Minute
+
+// detachedContext keeps the values of a context without its cancellation,
+// for cleanup to run after the context is done.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
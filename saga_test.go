@@ -0,0 +1,150 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_RunSaga(t *testing.T) {
+	var requests []string
+	bodies := map[string]map[string]interface{}{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodPost {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			bodies[r.URL.Path] = body
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/organizations":
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		case "POST /v1/persons":
+			w.Write([]byte(`{"success": true, "data": {"id": 2}}`))
+		case "POST /v1/deals":
+			if bodies[r.URL.Path]["title"] == "Fail" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(`{"success": true, "data": {"id": 3}}`))
+		case "POST /v1/notes":
+			w.Write([]byte(`{"success": true, "data": {"id": 4}}`))
+		case "DELETE /v1/organizations/1":
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		case "DELETE /v1/persons/2":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success": false, "error": "Forbidden"}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	steps := func(title string) []SagaStep {
+		// The steps are given out of order, the deal and note first.
+		return []SagaStep{
+			{Name: "note", Object: OBJECT_NOTE, Data: map[string]interface{}{"content": "Welcome"}, Refs: map[string]string{"deal_id": "deal"}},
+			{Name: "deal", Object: OBJECT_DEAL, Data: &DealCreateOptions{Title: title}, Refs: map[string]string{"org_id": "org", "person_id": "person"}},
+			{Name: "org", Object: OBJECT_ORGANIZATION, Data: &OrganizationCreateOptions{Name: "Acme"}},
+			{Name: "person", Object: OBJECT_PERSON, Data: &PersonCreateOptions{Name: "Jane"}, Refs: map[string]string{"org_id": "org"}},
+		}
+	}
+
+	result, err := client.RunSaga(ctx, steps("Deal"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := map[string]int{"org": 1, "person": 2, "deal": 3, "note": 4}; result.Status != SAGA_COMPLETED || !reflect.DeepEqual(result.IDs(), want) {
+		t.Errorf("Got result %v, want IDs %v", result, want)
+	}
+
+	if deal := bodies["/v1/deals"]; deal["org_id"] != 1.0 || deal["person_id"] != 2.0 || bodies["/v1/notes"]["deal_id"] != 3.0 {
+		t.Errorf("Got bodies %v", bodies)
+	}
+
+	requests = nil
+
+	result, err = client.RunSaga(ctx, steps("Fail"))
+
+	var sagaError *SagaError
+
+	if !errors.As(err, &sagaError) || sagaError.Step != "deal" || len(sagaError.Orphans) != 1 || sagaError.Orphans[0].ID != 2 {
+		t.Fatalf("Got error %v, want the deal failing and the person orphaned", err)
+	}
+
+	// The deal is posted once, the 502 possibly hiding a deal created.
+	want := []string{"POST /v1/organizations", "POST /v1/persons", "POST /v1/deals", "DELETE /v1/persons/2", "DELETE /v1/organizations/1"}
+
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Got requests %v, want %v", requests, want)
+	}
+
+	var statuses []SagaStepStatus
+
+	for _, step := range result.Steps {
+		statuses = append(statuses, step.Status)
+	}
+
+	if want := []SagaStepStatus{SAGA_STEP_COMPENSATED, SAGA_STEP_ORPHANED, SAGA_STEP_FAILED, SAGA_STEP_SKIPPED}; result.Status != SAGA_COMPENSATION_FAILED || !reflect.DeepEqual(statuses, want) || result.FailedStep != "deal" {
+		t.Errorf("Got result %v, want statuses %v", result, want)
+	}
+
+	if _, err := client.RunSaga(ctx, []SagaStep{
+		{Name: "a", Object: OBJECT_DEAL, Refs: map[string]string{"org_id": "b"}},
+		{Name: "b", Object: OBJECT_ORGANIZATION, Refs: map[string]string{"owner_id": "a"}},
+	}); err == nil {
+		t.Error("Got no error for a cycle")
+	}
+}
+
+func TestClient_RunSagaCompensationTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/organizations":
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		case "POST /v1/deals":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "error": "Invalid stage"}`))
+		case "DELETE /v1/organizations/1":
+			<-release
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	defer func(timeout time.Duration) {
+		sagaCompensationTimeout = timeout
+	}(sagaCompensationTimeout)
+
+	sagaCompensationTimeout = 10 * time.Millisecond
+
+	result, err := client.RunSaga(context.Background(), []SagaStep{
+		{Name: "org", Object: OBJECT_ORGANIZATION, Data: &OrganizationCreateOptions{Name: "Acme"}},
+		{Name: "deal", Object: OBJECT_DEAL, Data: &DealCreateOptions{Title: "Deal"}, Refs: map[string]string{"org_id": "org"}},
+	})
+
+	var sagaError *SagaError
+
+	if !errors.As(err, &sagaError) || len(sagaError.Orphans) != 1 || sagaError.Orphans[0].ID != 1 {
+		t.Fatalf("Got error %v, want the organization orphaned", err)
+	}
+
+	if result.Status != SAGA_COMPENSATION_FAILED || result.Steps[0].Status != SAGA_STEP_ORPHANED {
+		t.Errorf("Got result %v", result)
+	}
+}
@@ -0,0 +1,380 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ColumnSchema describes a field of an entity as a flat column.
+type ColumnSchema struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+
+	// Type is the JSON Schema type of the value of the field, as read:
+	// "string", "number", "integer", "boolean", "object" or "array". The
+	// values of references to users, persons and organizations are objects
+	// holding the ID under "value", though they are written as the ID.
+	Type string `json:"type"`
+
+	// Format refines the string type: "date", "date-time" or "time".
+	Format string `json:"format,omitempty"`
+
+	// FieldType is the Pipedrive field type, empty for values that are not
+	// fields, such as counters.
+	FieldType FieldType `json:"field_type,omitempty"`
+
+	// Options lists the options of enum and set fields.
+	Options []Option `json:"options,omitempty"`
+
+	// Nullable is set for all the columns but the ID, Pipedrive returning
+	// null for empty values.
+	Nullable bool `json:"nullable"`
+
+	// Custom is set for custom fields.
+	Custom bool `json:"custom"`
+
+	// Writable is set for fields whose value can be edited, from their
+	// EditFlag or BulkEditAllowed flags.
+	Writable bool `json:"writable"`
+
+	// BulkEditable is set for fields that can be bulk edited.
+	BulkEditable bool `json:"bulk_editable"`
+
+	// Mandatory is set for fields that must be set.
+	Mandatory bool `json:"mandatory"`
+}
+
+func (c ColumnSchema) String() string {
+	return Stringify(c)
+}
+
+// JSONSchema is a JSON Schema document.
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        interface{}            `json:"type,omitempty"` // string or []string
+	Format      string                 `json:"format,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"`
+	ReadOnly    bool                   `json:"readOnly,omitempty"`
+}
+
+// EntitySchema describes the records of an object.
+type EntitySchema struct {
+	Object  EventObject    `json:"object"`
+	Columns []ColumnSchema `json:"columns"`
+
+	entity reflect.Type
+}
+
+func (s EntitySchema) String() string {
+	return Stringify(s)
+}
+
+// Column returns the column with the given key.
+func (s EntitySchema) Column(key string) (ColumnSchema, bool) {
+	for _, column := range s.Columns {
+		if column.Key == key {
+			return column, true
+		}
+	}
+
+	return ColumnSchema{}, false
+}
+
+// schemaEntities maps objects to their entity type.
+var schemaEntities = map[EventObject]reflect.Type{
+	OBJECT_ACTIVITY:     reflect.TypeOf(Activity{}),
+	OBJECT_DEAL:         reflect.TypeOf(Deal{}),
+	OBJECT_NOTE:         reflect.TypeOf(Note{}),
+	OBJECT_ORGANIZATION: reflect.TypeOf(Organization{}),
+	OBJECT_PERSON:       reflect.TypeOf(Person{}),
+	OBJECT_PRODUCT:      reflect.TypeOf(Product{}),
+}
+
+var customFieldKey = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Schema returns the schema of the records of object, combining its entity
+// type with its fields. Deals, persons, organizations, products,
+// activities and notes are supported.
+func (c *Client) Schema(ctx context.Context, object EventObject) (*EntitySchema, error) {
+	if _, ok := schemaEntities[object]; !ok {
+		return nil, fmt.Errorf("object %q has no schema", object)
+	}
+
+	metadata, err := c.FieldMetadata(ctx, object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEntitySchema(object, metadata)
+}
+
+// NewEntitySchema returns the schema of the records of object from field
+// metadata, such as returned by Client.FieldMetadata. Fields come first,
+// in order, followed by the other values of the entity type, which are
+// read only.
+func NewEntitySchema(object EventObject, metadata FieldMetadata) (*EntitySchema, error) {
+	entity, ok := schemaEntities[object]
+
+	if !ok {
+		return nil, fmt.Errorf("object %q has no schema", object)
+	}
+
+	schema := &EntitySchema{
+		Object: object,
+		entity: entity,
+	}
+
+	known := map[string]bool{}
+
+	for _, field := range metadata[object] {
+		// Subfields, such as the currency of monetary fields, are values
+		// of the entity already.
+		if known[field.Key] {
+			continue
+		}
+
+		known[field.Key] = true
+
+		column := ColumnSchema{
+			Key:          field.Key,
+			Name:         field.Name,
+			FieldType:    field.FieldType,
+			Options:      field.Options,
+			Nullable:     field.Key != "id",
+			Custom:       customFieldKey.MatchString(field.Key),
+			Writable:     field.EditFlag || field.BulkEditAllowed,
+			BulkEditable: field.BulkEditAllowed,
+			Mandatory:    field.Mandatory,
+		}
+
+		column.Type, column.Format = fieldSchemaType(field.FieldType)
+
+		if column.Type == "" {
+			if f, ok := entityField(entity, field.Key); ok {
+				column.Type, column.Format = goSchemaType(f.Type)
+			}
+		}
+
+		schema.Columns = append(schema.Columns, column)
+	}
+
+	for i := 0; i < entity.NumField(); i++ {
+		f := entity.Field(i)
+		key := jsonKey(f)
+
+		if key == "" || known[key] {
+			continue
+		}
+
+		known[key] = true
+
+		column := ColumnSchema{
+			Key:      key,
+			Name:     key,
+			Nullable: key != "id",
+		}
+
+		column.Type, column.Format = goSchemaType(f.Type)
+
+		schema.Columns = append(schema.Columns, column)
+	}
+
+	return schema, nil
+}
+
+// JSONSchema returns a JSON Schema document describing the records of the
+// object as returned by the API. Properties of the entity type are
+// described from its Go type, custom fields from their field type.
+func (s EntitySchema) JSONSchema() *JSONSchema {
+	document := &JSONSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      string(s.Object),
+		Type:       "object",
+		Properties: map[string]*JSONSchema{},
+	}
+
+	for _, column := range s.Columns {
+		var property *JSONSchema
+
+		if f, ok := entityField(s.entity, column.Key); ok {
+			property = goJSONSchema(f.Type, 0)
+		} else {
+			property = &JSONSchema{Type: column.Type, Format: column.Format}
+		}
+
+		if column.Type == "object" && property.Properties == nil {
+			property.Properties = map[string]*JSONSchema{"value": {Type: "integer"}}
+		}
+
+		if column.Name != column.Key {
+			property.Title = column.Name
+		}
+
+		if column.FieldType == FieldTypeEnum {
+			for _, option := range column.Options {
+				property.Enum = append(property.Enum, optionID(option.ID))
+			}
+		}
+
+		if column.Nullable {
+			property.Type = nullable(property.Type)
+
+			if property.Enum != nil {
+				property.Enum = append(property.Enum, nil)
+			}
+		}
+
+		property.ReadOnly = !column.Writable
+
+		if column.Mandatory {
+			document.Required = append(document.Required, column.Key)
+		}
+
+		document.Properties[column.Key] = property
+	}
+
+	return document
+}
+
+// fieldSchemaType returns the JSON Schema type and format of the values of
+// a field type.
+func fieldSchemaType(fieldType FieldType) (string, string) {
+	switch fieldType {
+	case FieldTypeDouble, FieldTypeMonetary:
+		return "number", ""
+	case FieldTypeInt, FieldTypeStage:
+		return "integer", ""
+	case FieldTypeUser, FieldTypeOrg, FieldTypePeople:
+		return "object", ""
+	case FieldTypeDate, FieldTypeDaterange:
+		return "string", "date"
+	case FieldTypeTime, FieldTypeTimerange:
+		return "string", "time"
+	case FieldTypeVarchar, FieldTypeVarcharAuto, FieldTypeText, FieldTypeVarcharOptions,
+		FieldTypeAddress, FieldTypePhone, FieldTypeEnum, FieldTypeSet, FieldTypeStatus:
+		return "string", ""
+	}
+
+	return "", ""
+}
+
+var (
+	timestampType = reflect.TypeOf(Timestamp{})
+	dateType      = reflect.TypeOf(Date{})
+)
+
+// goSchemaType returns the JSON Schema type and format of the values of a
+// Go type.
+func goSchemaType(t reflect.Type) (string, string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timestampType:
+		return "string", "date-time"
+	case dateType:
+		return "string", "date"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string", ""
+	case reflect.Bool:
+		return "boolean", ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer", ""
+	case reflect.Float32, reflect.Float64:
+		return "number", ""
+	case reflect.Struct, reflect.Map:
+		return "object", ""
+	case reflect.Slice, reflect.Array:
+		return "array", ""
+	}
+
+	return "", ""
+}
+
+// goJSONSchema describes a Go type, nested structs included.
+func goJSONSchema(t reflect.Type, depth int) *JSONSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	typ, format := goSchemaType(t)
+	schema := &JSONSchema{Format: format}
+
+	if typ != "" {
+		schema.Type = typ
+	}
+
+	// Entities nest few levels, the limit guards against recursive types.
+	if depth > 3 {
+		return schema
+	}
+
+	switch {
+	case typ == "object" && t.Kind() == reflect.Struct:
+		schema.Properties = map[string]*JSONSchema{}
+
+		for i := 0; i < t.NumField(); i++ {
+			if key := jsonKey(t.Field(i)); key != "" {
+				schema.Properties[key] = goJSONSchema(t.Field(i).Type, depth+1)
+			}
+		}
+
+	case typ == "array":
+		schema.Items = goJSONSchema(t.Elem(), depth+1)
+	}
+
+	return schema
+}
+
+// nullable adds "null" to a JSON Schema type.
+func nullable(typ interface{}) interface{} {
+	switch t := typ.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{t, "null"}
+	}
+
+	return typ
+}
+
+// entityField returns the field of an entity type with the given JSON key.
+func entityField(entity reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < entity.NumField(); i++ {
+		if jsonKey(entity.Field(i)) == key {
+			return entity.Field(i), true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// jsonKey returns the JSON key of a struct field, or an empty string if
+// the field is not encoded.
+func jsonKey(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+
+	key := strings.Split(f.Tag.Get("json"), ",")[0]
+
+	if key == "-" {
+		return ""
+	}
+
+	return key
+}
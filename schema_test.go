@@ -0,0 +1,70 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewEntitySchema(t *testing.T) {
+	metadata := FieldMetadata{
+		OBJECT_DEAL: {
+			{ID: 1, Key: "id", Name: "ID", FieldType: FieldTypeInt},
+			{ID: 2, Key: "title", Name: "Title", FieldType: FieldTypeVarchar, BulkEditAllowed: true, Mandatory: true},
+			{ID: 3, Key: "org_id", Name: "Organization", FieldType: FieldTypeOrg, BulkEditAllowed: true},
+			{ID: 4, Key: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", Name: "Segment", FieldType: FieldTypeEnum, EditFlag: true, Options: []Option{{ID: 7.0, Label: "SMB"}}},
+			{ID: 5, Key: "b1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", Name: "Partner", FieldType: FieldTypeOrg, EditFlag: true},
+		},
+	}
+
+	schema, err := NewEntitySchema(OBJECT_DEAL, metadata)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ColumnSchema{
+		{Key: "id", Name: "ID", Type: "integer", FieldType: FieldTypeInt},
+		{Key: "title", Name: "Title", Type: "string", FieldType: FieldTypeVarchar, Nullable: true, Writable: true, BulkEditable: true, Mandatory: true},
+		{Key: "org_id", Name: "Organization", Type: "object", FieldType: FieldTypeOrg, Nullable: true, Writable: true, BulkEditable: true},
+		{Key: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", Name: "Segment", Type: "string", FieldType: FieldTypeEnum, Options: []Option{{ID: 7.0, Label: "SMB"}}, Nullable: true, Custom: true, Writable: true},
+	}
+
+	if !reflect.DeepEqual(schema.Columns[:4], want) {
+		t.Errorf("Got columns %v, want %v", schema.Columns[:4], want)
+	}
+
+	column, ok := schema.Column("add_time")
+
+	if !ok || column.Type != "string" || column.Writable {
+		t.Errorf("Got add_time column %v", column)
+	}
+
+	b, err := json.Marshal(schema.JSONSchema().Properties["a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"title":"Segment","type":["string","null"],"enum":["7",null]}` {
+		t.Errorf("Got custom field schema %s", b)
+	}
+
+	org := schema.JSONSchema().Properties["org_id"]
+
+	if org.Properties["value"] == nil || org.ReadOnly {
+		t.Errorf("Got org_id schema %v", Stringify(org))
+	}
+
+	// Custom references are described as the objects they are read as,
+	// like the references of the entity.
+	b, err = json.Marshal(schema.JSONSchema().Properties["b1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"title":"Partner","type":["object","null"],"properties":{"value":{"type":"integer"}}}` {
+		t.Errorf("Got custom reference schema %s", b)
+	}
+}
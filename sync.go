@@ -0,0 +1,410 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SyncCheckpoint records how far the changes of an item were synced.
+type SyncCheckpoint struct {
+	// Timestamp is the time of the last change synced, in UTC.
+	Timestamp string `json:"timestamp"`
+
+	// Seen lists the changes synced at Timestamp. Recents are listed from
+	// a timestamp with a precision of one second, the inclusive lower bound
+	// returns them again and they are skipped.
+	Seen []string `json:"seen,omitempty"`
+}
+
+func (c SyncCheckpoint) String() string {
+	return Stringify(c)
+}
+
+// CheckpointStore persists sync checkpoints, keyed by item.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under key, or nil if there is none.
+	Load(ctx context.Context, key string) (*SyncCheckpoint, error)
+
+	// Save replaces the checkpoint saved under key.
+	Save(ctx context.Context, key string, checkpoint *SyncCheckpoint) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]SyncCheckpoint
+}
+
+// NewMemoryCheckpointStore returns an empty in-memory checkpoint store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]SyncCheckpoint{}}
+}
+
+// Load returns the checkpoint saved under key, or nil if there is none.
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) (*SyncCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	checkpoint.Seen = append([]string(nil), checkpoint.Seen...)
+
+	return &checkpoint, nil
+}
+
+// Save replaces the checkpoint saved under key.
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, checkpoint *SyncCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *checkpoint
+	saved.Seen = append([]string(nil), checkpoint.Seen...)
+	s.checkpoints[key] = saved
+
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints in a JSON file. The file is
+// replaced atomically on each save.
+type FileCheckpointStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileCheckpointStore returns a checkpoint store using the file at path,
+// which is created on the first save.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load returns the checkpoint saved under key, or nil if there is none.
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) (*SyncCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, ok := checkpoints[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &checkpoint, nil
+}
+
+// Save replaces the checkpoint saved under key.
+func (s *FileCheckpointStore) Save(ctx context.Context, key string, checkpoint *SyncCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+
+	if err != nil {
+		return err
+	}
+
+	checkpoints[key] = *checkpoint
+
+	return writeJSONFile(s.Path, checkpoints)
+}
+
+func (s *FileCheckpointStore) read() (map[string]SyncCheckpoint, error) {
+	checkpoints := map[string]SyncCheckpoint{}
+
+	if err := readJSONFile(s.Path, &checkpoints); err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+// readJSONFile decodes the JSON file at path into v, leaving v unchanged if
+// the file does not exist.
+func readJSONFile(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	return nil
+}
+
+// writeJSONFile atomically replaces the file at path with v encoded as JSON.
+func writeJSONFile(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Type of sync operations.
+type SyncOperation string
+
+const (
+	SYNC_UPSERT SyncOperation = "upsert"
+	SYNC_DELETE SyncOperation = "delete"
+)
+
+// SyncChange is a change of a record emitted by a Syncer.
+type SyncChange struct {
+	Operation SyncOperation
+	Item      RecentItem
+	ID        int
+
+	// Record is the record as its entity type, such as *Deal, see
+	// RecentRecord.Decode. It is nil for deleted records whose data is
+	// not returned.
+	Record interface{}
+
+	// Raw is the record as returned by the API.
+	Raw json.RawMessage
+
+	// Timestamp is the time of the change, in UTC.
+	Timestamp string
+}
+
+func (c SyncChange) String() string {
+	return Stringify(c)
+}
+
+// Syncer emits the changes of records since the last sync, using the
+// Recents endpoint. The progress of each item is saved in a checkpoint
+// store after each page, so an interrupted sync resumes where it stopped.
+type Syncer struct {
+	client *Client
+	store  CheckpointStore
+	items  []RecentItem
+
+	// Since is the time changes are synced from for items without a
+	// checkpoint. By default all records are synced.
+	Since time.Time
+
+	// PageSize is the number of changes requested per page, up to 500. It
+	// defaults to 100.
+	PageSize uint
+}
+
+// NewSyncer returns a syncer emitting the changes of items, saving its
+// checkpoints in store.
+func (c *Client) NewSyncer(store CheckpointStore, items ...RecentItem) *Syncer {
+	return &Syncer{
+		client: c,
+		store:  store,
+		items:  items,
+	}
+}
+
+// Sync sends the changes since the last sync on changes, item by item and
+// in the order they happened, and returns once all the changes were sent.
+// It does not close changes.
+//
+// The checkpoint of an item is saved once the changes of a page were
+// received, so changes received before a failure may be sent again on the
+// next sync. Use an unbuffered channel and process changes before receiving
+// the next one for them to be synced at least once.
+func (s *Syncer) Sync(ctx context.Context, changes chan<- SyncChange) error {
+	for _, item := range s.items {
+		if err := s.syncItem(ctx, item, changes); err != nil {
+			return fmt.Errorf("sync %s: %w", item, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) syncItem(ctx context.Context, item RecentItem, changes chan<- SyncChange) error {
+	checkpoint, err := s.store.Load(ctx, string(item))
+
+	if err != nil {
+		return err
+	}
+
+	if checkpoint == nil {
+		since := s.Since
+
+		if since.IsZero() {
+			since = time.Unix(0, 0)
+		}
+
+		checkpoint = &SyncCheckpoint{Timestamp: since.UTC().Format(DateTimeLayout)}
+	}
+
+	limit := s.PageSize
+
+	if limit == 0 {
+		limit = 100
+	}
+
+	since := checkpoint.Timestamp
+	seen := map[string]bool{}
+
+	for _, key := range checkpoint.Seen {
+		seen[key] = true
+	}
+
+	var start uint
+
+	for {
+		record, _, err := s.client.Recents.List(ctx, &RecentsListOptions{
+			SinceTimestamp: since,
+			Items:          string(item),
+			Start:          start,
+			Limit:          limit,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, recent := range record.Data {
+			change, err := syncChange(recent)
+
+			if err != nil {
+				return err
+			}
+
+			if change.Timestamp == "" {
+				change.Timestamp = record.AdditionalData.LastTimestampOnPage
+			}
+
+			key := fmt.Sprintf("%d@%s", change.ID, change.Timestamp)
+
+			if seen[key] {
+				continue
+			}
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if change.Timestamp > checkpoint.Timestamp {
+				checkpoint.Timestamp = change.Timestamp
+				checkpoint.Seen = nil
+			}
+
+			if change.Timestamp == checkpoint.Timestamp {
+				checkpoint.Seen = append(checkpoint.Seen, key)
+			}
+
+			seen[key] = true
+		}
+
+		if err := s.store.Save(ctx, string(item), checkpoint); err != nil {
+			return err
+		}
+
+		pagination := record.AdditionalData.Pagination
+
+		if !pagination.MoreItemsInCollection {
+			return nil
+		}
+
+		start = uint(pagination.NextStart)
+	}
+}
+
+// syncChange converts a recent record to a change.
+func syncChange(recent RecentRecord) (SyncChange, error) {
+	change := SyncChange{
+		Operation: SYNC_UPSERT,
+		Item:      RecentItem(recent.Item),
+		ID:        recent.ID,
+		Raw:       recent.RawData,
+	}
+
+	record, err := recent.Decode()
+
+	if err != nil {
+		return change, fmt.Errorf("%s %d: %w", recent.Item, recent.ID, err)
+	}
+
+	change.Record = record
+
+	values, err := recordValues(recent.RawData)
+
+	if err != nil {
+		// The data of users is a list, use the decoded user instead.
+		values, err = recordValues(record)
+
+		if err != nil {
+			return change, fmt.Errorf("%s %d: %w", recent.Item, recent.ID, err)
+		}
+	}
+
+	if record == nil || softDeleted(values) {
+		change.Operation = SYNC_DELETE
+	}
+
+	for _, key := range []string{"update_time", "modified", "add_time", "created"} {
+		if timestamp, ok := values[key].(string); ok && timestamp != "" {
+			change.Timestamp = timestamp
+			break
+		}
+	}
+
+	return change, nil
+}
+
+// softDeleted reports whether the values of a record mark it deleted.
+func softDeleted(values map[string]interface{}) bool {
+	if status, ok := values["status"].(string); ok && status == "deleted" {
+		return true
+	}
+
+	if deleted, ok := values["deleted"].(bool); ok && deleted {
+		return true
+	}
+
+	if active, ok := values["active_flag"].(bool); ok && !active {
+		return true
+	}
+
+	return false
+}
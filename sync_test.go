@@ -0,0 +1,104 @@
+package pipedrive
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	pages := map[string]string{
+		"1970-01-01 00:00:00/0": `{"success": true, "data": [
+			{"item": "deal", "id": 1, "data": {"id": 1, "title": "One", "update_time": "2026-01-01 10:00:00"}},
+			{"item": "deal", "id": 2, "data": {"id": 2, "title": "Two", "update_time": "2026-01-01 10:00:05"}}
+		], "additional_data": {"last_timestamp_on_page": "2026-01-01 10:00:05", "pagination": {"more_items_in_collection": true, "next_start": 2}}}`,
+		"1970-01-01 00:00:00/2": `{"success": true, "data": [
+			{"item": "deal", "id": 3, "data": {"id": 3, "title": "Three", "update_time": "2026-01-01 10:00:05", "deleted": true}}
+		], "additional_data": {"last_timestamp_on_page": "2026-01-01 10:00:05"}}`,
+		"2026-01-01 10:00:05/0": `{"success": true, "data": [
+			{"item": "deal", "id": 2, "data": {"id": 2, "title": "Two", "update_time": "2026-01-01 10:00:05"}},
+			{"item": "deal", "id": 3, "data": {"id": 3, "title": "Three", "update_time": "2026-01-01 10:00:05", "deleted": true}},
+			{"item": "deal", "id": 4, "data": {"id": 4, "title": "Four", "update_time": "2026-01-01 10:00:05"}}
+		], "additional_data": {"last_timestamp_on_page": "2026-01-01 10:00:05"}}`,
+	}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		if query.Get("items") != "deal" {
+			t.Errorf("Got items %q", query.Get("items"))
+		}
+
+		start := query.Get("start")
+
+		if start == "" {
+			start = "0"
+		}
+
+		page, ok := pages[query.Get("since_timestamp")+"/"+start]
+
+		if !ok {
+			t.Errorf("Got unexpected request %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(page))
+	}))
+
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+
+	sync := func() []SyncChange {
+		changes := make(chan SyncChange)
+		done := make(chan error, 1)
+
+		go func() {
+			done <- client.NewSyncer(store, RECENT_ITEM_DEAL).Sync(context.Background(), changes)
+			close(changes)
+		}()
+
+		var received []SyncChange
+
+		for change := range changes {
+			received = append(received, change)
+		}
+
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+
+		return received
+	}
+
+	changes := sync()
+
+	if len(changes) != 3 {
+		t.Fatalf("Got %d changes, want 3", len(changes))
+	}
+
+	if deal, ok := changes[0].Record.(*Deal); !ok || deal.Title != "One" || changes[0].Operation != SYNC_UPSERT {
+		t.Errorf("Got first change %v", changes[0])
+	}
+
+	if changes[2].ID != 3 || changes[2].Operation != SYNC_DELETE {
+		t.Errorf("Got last change %v", changes[2])
+	}
+
+	checkpoint, err := store.Load(context.Background(), "deal")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if checkpoint.Timestamp != "2026-01-01 10:00:05" || len(checkpoint.Seen) != 2 {
+		t.Errorf("Got checkpoint %v", checkpoint)
+	}
+
+	// Deals 2 and 3 are returned again at the checkpoint timestamp.
+	changes = sync()
+
+	if len(changes) != 1 || changes[0].ID != 4 {
+		t.Errorf("Got changes %v, want deal 4", changes)
+	}
+}
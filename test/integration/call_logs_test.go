@@ -0,0 +1,22 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestCallLogsService_List(t *testing.T) {
+	result, _, err := client.CallLogs.List(context.Background(), &pipedrive.PaginationOptions{
+		Limit: 10,
+	})
+
+	if err != nil {
+		t.Errorf("Could not get call logs: %v", err)
+	}
+
+	if result.Success != true {
+		t.Error("Got invalid result")
+	}
+}
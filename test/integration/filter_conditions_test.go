@@ -0,0 +1,43 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestFilterBuilder_Build(t *testing.T) {
+	metadata, err := client.FieldMetadata(context.Background(), pipedrive.OBJECT_DEAL)
+
+	if err != nil {
+		t.Fatalf("Could not get deal fields: %v", err)
+	}
+
+	conditions, err := pipedrive.NewFilterBuilder(pipedrive.OBJECT_DEAL).
+		And(pipedrive.Field("value").Gt(1000)).
+		And(pipedrive.Field("add_time").Between("2020-01-01", pipedrive.FilterDateToday)).
+		Build(metadata)
+
+	if err != nil {
+		t.Fatalf("Could not build conditions: %v", err)
+	}
+
+	builder, err := pipedrive.ParseFilterConditions(pipedrive.OBJECT_DEAL, conditions)
+
+	if err != nil {
+		t.Fatalf("Could not parse conditions: %v", err)
+	}
+
+	if err := builder.Validate(metadata); err != nil {
+		t.Errorf("Parsed conditions are invalid: %v", err)
+	}
+
+	_, err = pipedrive.NewFilterBuilder(pipedrive.OBJECT_DEAL).
+		And(pipedrive.Field("value").Contains("1000")).
+		Build(metadata)
+
+	if err == nil {
+		t.Error("Expected an error for an operator not allowed on the field")
+	}
+}
@@ -0,0 +1,34 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestLeadsService_List(t *testing.T) {
+	result, _, err := client.Leads.List(context.Background(), &pipedrive.LeadsListOptions{
+		Limit: 10,
+	})
+
+	if err != nil {
+		t.Errorf("Could not get leads: %v", err)
+	}
+
+	if result.Success != true {
+		t.Error("Got invalid result")
+	}
+}
+
+func TestLeadSourcesService_List(t *testing.T) {
+	result, _, err := client.LeadSources.List(context.Background())
+
+	if err != nil {
+		t.Errorf("Could not get lead sources: %v", err)
+	}
+
+	if result.Success != true {
+		t.Error("Got invalid result")
+	}
+}
@@ -0,0 +1,23 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestMailboxService_ListThreads(t *testing.T) {
+	result, _, err := client.Mailbox.ListThreads(context.Background(), &pipedrive.MailThreadsListOptions{
+		Folder: pipedrive.MailFolderInbox,
+		Limit:  10,
+	})
+
+	if err != nil {
+		t.Errorf("Could not get mail threads: %v", err)
+	}
+
+	if result.Success != true {
+		t.Error("Got invalid result")
+	}
+}
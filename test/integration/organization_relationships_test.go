@@ -0,0 +1,36 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestOrganizationRelationshipsService_BuildHierarchy(t *testing.T) {
+	orgs, _, err := client.Organizations.List(context.Background(), &pipedrive.OrganizationsListOptions{
+		Limit: 1,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not get organizations: %v", err)
+	}
+
+	if len(orgs.Data) == 0 {
+		t.Skip("No organizations to build a hierarchy from")
+	}
+
+	root, err := client.OrganizationRelationships.BuildHierarchy(context.Background(), orgs.Data[0], nil)
+
+	if err != nil {
+		t.Fatalf("Could not build hierarchy: %v", err)
+	}
+
+	if root.Find(orgs.Data[0].ID) == nil {
+		t.Error("Hierarchy does not contain the starting organization")
+	}
+
+	if root.Total.Organizations < 1 {
+		t.Error("Got invalid rollup")
+	}
+}
@@ -0,0 +1,20 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func TestClient_Schema(t *testing.T) {
+	schema, err := client.Schema(context.Background(), pipedrive.OBJECT_DEAL)
+
+	if err != nil {
+		t.Fatalf("Could not get deal schema: %v", err)
+	}
+
+	if column, ok := schema.Column("title"); !ok || column.Type != "string" {
+		t.Errorf("Got invalid title column: %v", column)
+	}
+}
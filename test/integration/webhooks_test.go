@@ -3,6 +3,8 @@ package integration
 import (
 	"context"
 	"testing"
+
+	"github.com/polytomic/pipedrive-api"
 )
 
 func TestWebhooksService_List(t *testing.T) {
@@ -16,3 +18,28 @@ func TestWebhooksService_List(t *testing.T) {
 		t.Error("Got invalid result")
 	}
 }
+
+func TestWebhooksService_Reconcile(t *testing.T) {
+	plan, result, err := client.Webhooks.Reconcile(context.Background(), []pipedrive.WebhookSubscription{
+		{
+			SubscriptionURL: "https://example.invalid/pipedrive",
+			EventAction:     pipedrive.ACTION_ALL,
+			EventObject:     pipedrive.OBJECT_DEAL,
+		},
+	}, &pipedrive.WebhookReconcileOptions{
+		URLPrefix: "https://example.invalid/",
+		DryRun:    true,
+	})
+
+	if err != nil {
+		t.Fatalf("Could not plan webhooks: %v", err)
+	}
+
+	if result != nil {
+		t.Error("Dry run applied the plan")
+	}
+
+	if len(plan.Create)+len(plan.Keep) != 1 {
+		t.Errorf("Got invalid plan: %v", plan)
+	}
+}
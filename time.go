@@ -1,6 +1,9 @@
 package pipedrive
 
-import "time"
+import (
+	"bytes"
+	"time"
+)
 
 const (
 	DateLayout     = "2006-01-02"
@@ -22,3 +25,66 @@ func (t Timestamp) Format() string {
 func (t Timestamp) FormatFull() string {
 	return t.Time.Format(DateTimeLayout)
 }
+
+// Date represents a calendar date without a time of day, such as
+// the expected close date of a lead. The zero value encodes as null.
+type Date struct {
+	time.Time
+}
+
+func (d Date) String() string {
+	return d.Time.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(`"` + d.Time.Format(DateLayout) + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+
+	if len(b) == 0 || string(b) == "null" {
+		d.Time = time.Time{}
+		return nil
+	}
+
+	t, err := time.Parse(DateLayout, string(b))
+
+	if err != nil {
+		return err
+	}
+
+	d.Time = t
+
+	return nil
+}
+
+// UnmarshalJSON decodes timestamps in the "2006-01-02 15:04:05" UTC layout
+// used by the API, as well as RFC 3339, the layout timestamps are encoded
+// in. Empty values decode as the zero time.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+
+	if len(b) == 0 || string(b) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(DateTimeLayout, string(b))
+
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, string(b))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	t.Time = parsed
+
+	return nil
+}
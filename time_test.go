@@ -0,0 +1,47 @@
+package pipedrive
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_JSON(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		timestamp Timestamp
+		want      string
+	}{
+		{Timestamp{time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)}, `"2026-03-01T09:30:00Z"`},
+		{Timestamp{time.Date(2026, 3, 1, 10, 30, 0, 0, location)}, `"2026-03-01T10:30:00+01:00"`},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.timestamp)
+
+		if err != nil || string(b) != tt.want {
+			t.Errorf("Got %s and error %v encoding %v, want %s", b, err, tt.timestamp, tt.want)
+		}
+
+		var decoded Timestamp
+
+		if err := json.Unmarshal(b, &decoded); err != nil || !decoded.Equal(tt.timestamp.Time) {
+			t.Errorf("Got %v and error %v decoding %s, want %v", decoded, err, b, tt.timestamp)
+		}
+	}
+
+	var decoded Timestamp
+
+	if err := json.Unmarshal([]byte(`"2026-03-01 09:30:00"`), &decoded); err != nil || !decoded.Equal(tests[0].timestamp.Time) {
+		t.Errorf("Got %v and error %v decoding the API layout", decoded, err)
+	}
+
+	// Request bodies use the API layout.
+	b, err := json.Marshal(CallLogCreateOptions{StartTime: tests[0].timestamp, EndTime: tests[1].timestamp})
+
+	if err != nil || !strings.Contains(string(b), `"start_time":"2026-03-01 09:30:00"`) || !strings.Contains(string(b), `"end_time":"2026-03-01 09:30:00"`) {
+		t.Errorf("Got %s and error %v encoding call log options", b, err)
+	}
+}
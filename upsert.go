@@ -0,0 +1,397 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Policies resolving the match of several records by an upsert.
+type UpsertPolicy string
+
+const (
+	// Fail with an AmbiguousMatchError.
+	UPSERT_POLICY_ERROR UpsertPolicy = "error"
+	// Update the record with the lowest ID.
+	UPSERT_POLICY_FIRST UpsertPolicy = "first"
+	// Update the record updated last.
+	UPSERT_POLICY_MOST_RECENT UpsertPolicy = "most_recent"
+)
+
+// Actions taken by an upsert.
+type UpsertAction string
+
+const (
+	UPSERT_CREATED UpsertAction = "created"
+	UPSERT_UPDATED UpsertAction = "updated"
+	// A record matched and no update was given.
+	UPSERT_MATCHED UpsertAction = "matched"
+)
+
+// UpsertKey identifies the record an upsert creates or updates.
+type UpsertKey struct {
+	// Field is the key of the field matched, such as "email" or "phone"
+	// for persons, "name" for organizations, "title" for deals, or a
+	// custom field, by key or by name, such as "external_id".
+	Field string
+
+	// Value is the value matched, exactly.
+	Value string
+
+	// Policy resolves the match of several records. It defaults to
+	// UPSERT_POLICY_ERROR.
+	Policy UpsertPolicy
+}
+
+// UpsertResult reports what an upsert did.
+type UpsertResult struct {
+	Action UpsertAction `json:"action"`
+
+	// ID of the record created or updated.
+	ID int `json:"id"`
+
+	// Matches lists the IDs of the records matching the key.
+	Matches []int `json:"matches,omitempty"`
+
+	// Record is the *Person, *Organization or *Deal created or updated, or
+	// nil if only matched.
+	Record interface{} `json:"record,omitempty"`
+}
+
+func (r UpsertResult) String() string {
+	return Stringify(r)
+}
+
+// upsertSearchFields maps the standard fields of objects to the search
+// field matching them, other fields being searched as custom fields.
+var upsertSearchFields = map[EventObject]map[string]string{
+	OBJECT_PERSON: {
+		"email": "email",
+		"phone": "phone",
+		"name":  "name",
+	},
+	OBJECT_ORGANIZATION: {
+		"name":    "name",
+		"address": "address",
+	},
+	OBJECT_DEAL: {
+		"title": "title",
+	},
+}
+
+// UpsertPerson updates the person matching key with update, or creates one
+// with create if none matches. The key value should be set in create for
+// the person created to match next time. If update is nil, a matching
+// person is left unchanged. An error is returned if no person matches and
+// create is nil.
+func (c *Client) UpsertPerson(ctx context.Context, key UpsertKey, create *PersonCreateOptions, update *PersonUpdateOptions) (*UpsertResult, error) {
+	result, err := c.upsertMatch(ctx, OBJECT_PERSON, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case result.ID == 0 && create == nil:
+		return nil, errUpsertNoCreate(OBJECT_PERSON)
+
+	case result.ID == 0:
+		record, _, err := c.Persons.Create(ctx, create)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.ID, result.Record = UPSERT_CREATED, record.Data.ID, &record.Data
+
+	case update != nil:
+		record, _, err := c.Persons.Update(ctx, result.ID, update)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.Record = UPSERT_UPDATED, &record.Data
+	}
+
+	return result, nil
+}
+
+// UpsertOrganization updates the organization matching key with update, or
+// creates one with create if none matches. The key value should be set in
+// create for the organization created to match next time. If update is
+// nil, a matching organization is left unchanged. An error is returned if
+// no organization matches and create is nil.
+func (c *Client) UpsertOrganization(ctx context.Context, key UpsertKey, create *OrganizationCreateOptions, update *OrganizationUpdateOptions) (*UpsertResult, error) {
+	result, err := c.upsertMatch(ctx, OBJECT_ORGANIZATION, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case result.ID == 0 && create == nil:
+		return nil, errUpsertNoCreate(OBJECT_ORGANIZATION)
+
+	case result.ID == 0:
+		record, _, err := c.Organizations.Create(ctx, create)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.ID, result.Record = UPSERT_CREATED, record.Data.ID, &record.Data
+
+	case update != nil:
+		record, _, err := c.Organizations.Update(ctx, result.ID, update)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.Record = UPSERT_UPDATED, &record.Data
+	}
+
+	return result, nil
+}
+
+// UpsertDeal updates the deal matching key with update, or creates one with
+// create if none matches. The key value should be set in create for the
+// deal created to match next time. If update is nil, a matching deal is
+// left unchanged. An error is returned if no deal matches and create is
+// nil.
+func (c *Client) UpsertDeal(ctx context.Context, key UpsertKey, create *DealCreateOptions, update *DealsUpdateOptions) (*UpsertResult, error) {
+	result, err := c.upsertMatch(ctx, OBJECT_DEAL, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case result.ID == 0 && create == nil:
+		return nil, errUpsertNoCreate(OBJECT_DEAL)
+
+	case result.ID == 0:
+		record, _, err := c.Deals.Create(ctx, create)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.ID, result.Record = UPSERT_CREATED, record.Data.ID, &record.Data
+
+	case update != nil:
+		record, _, err := c.Deals.Update(ctx, result.ID, update)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Action, result.Record = UPSERT_UPDATED, &record.Data
+	}
+
+	return result, nil
+}
+
+// upsertMatch finds the records of object matching key and returns a
+// result with the ID of the record to update, or 0 if none matches.
+//
+// Exact searches match values containing the term as a word, and custom
+// field searches match any custom field, so the records found are fetched
+// and their value compared.
+func (c *Client) upsertMatch(ctx context.Context, object EventObject, key UpsertKey) (*UpsertResult, error) {
+	if key.Field == "" || key.Value == "" {
+		return nil, fmt.Errorf("upsert %s: key field and value are required", object)
+	}
+
+	switch key.Policy {
+	case "", UPSERT_POLICY_ERROR, UPSERT_POLICY_FIRST, UPSERT_POLICY_MOST_RECENT:
+	default:
+		return nil, fmt.Errorf("upsert %s: unknown policy %q", object, key.Policy)
+	}
+
+	fieldKey := key.Field
+	searchField, ok := upsertSearchFields[object][fieldKey]
+
+	if !ok {
+		metadata, err := c.FieldMetadata(ctx, object)
+
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok := metadata.ByKey(object, fieldKey)
+
+		if !ok {
+			for _, f := range metadata[object] {
+				if f.Name == key.Field {
+					field, ok = f, true
+					break
+				}
+			}
+		}
+
+		if !ok || !customFieldKey.MatchString(field.Key) {
+			return nil, fmt.Errorf("upsert %s: field %q cannot be searched", object, key.Field)
+		}
+
+		fieldKey, searchField = field.Key, "custom_fields"
+	}
+
+	ids, err := c.upsertSearch(ctx, object, searchField, key.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UpsertResult{Action: UPSERT_MATCHED}
+
+	var latest string
+
+	for _, id := range ids {
+		data, err := c.getRecord(ctx, object, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := recordValues(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !upsertValueMatches(values[fieldKey], key.Value) {
+			continue
+		}
+
+		result.Matches = append(result.Matches, id)
+
+		updated, _ := values["update_time"].(string)
+
+		if result.ID == 0 || (key.Policy == UPSERT_POLICY_MOST_RECENT && updated > latest) ||
+			(key.Policy != UPSERT_POLICY_MOST_RECENT && id < result.ID) {
+			result.ID, latest = id, updated
+		}
+	}
+
+	if len(result.Matches) > 1 && (key.Policy == "" || key.Policy == UPSERT_POLICY_ERROR) {
+		return nil, &AmbiguousMatchError{
+			Object: object,
+			Field:  key.Field,
+			Value:  key.Value,
+			IDs:    result.Matches,
+		}
+	}
+
+	return result, nil
+}
+
+// errUpsertNoCreate returns the error of an upsert matching no record
+// without a create.
+func errUpsertNoCreate(object EventObject) error {
+	return fmt.Errorf("upsert %s: no record matches and no create is given", object)
+}
+
+// upsertSearch returns the IDs of the records of object found searching
+// field for value.
+func (c *Client) upsertSearch(ctx context.Context, object EventObject, field, value string) ([]int, error) {
+	var ids []int
+
+	for start := 0; ; {
+		var pagination Pagination
+
+		switch object {
+		case OBJECT_PERSON:
+			record, _, err := c.Persons.Search(ctx, PersonSearchParams{
+				Term:       value,
+				Fields:     []string{field},
+				ExactMatch: true,
+				Start:      start,
+				Limit:      100,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range record.Data.Items {
+				ids = append(ids, item.Item.ID)
+			}
+
+			pagination = record.AdditionalData.Pagination
+
+		case OBJECT_ORGANIZATION:
+			record, _, err := c.Organizations.Search(ctx, OrganizationSearchParams{
+				Term:       value,
+				Fields:     []string{field},
+				ExactMatch: true,
+				Start:      start,
+				Limit:      100,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range record.Data.Items {
+				ids = append(ids, item.Item.ID)
+			}
+
+			pagination = record.AdditionalData.Pagination
+
+		case OBJECT_DEAL:
+			record, _, err := c.Deals.Search(ctx, DealSearchParams{
+				Term:       value,
+				Fields:     []string{field},
+				ExactMatch: true,
+				Start:      start,
+				Limit:      100,
+			})
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range record.Data.Items {
+				ids = append(ids, item.Item.ID)
+			}
+
+			pagination = record.AdditionalData.Pagination
+		}
+
+		if !pagination.MoreItemsInCollection {
+			return ids, nil
+		}
+
+		start = pagination.NextStart
+	}
+}
+
+// upsertValueMatches reports whether a field value equals the value of an
+// upsert key. Emails and phones match if any of their values does, emails
+// ignoring case.
+func upsertValueMatches(value interface{}, want string) bool {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if upsertValueMatches(item, want) {
+				return true
+			}
+		}
+
+		return false
+
+	case map[string]interface{}:
+		return upsertValueMatches(v["value"], want)
+
+	case string:
+		if strings.Contains(want, "@") {
+			return strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(want))
+		}
+
+		return strings.TrimSpace(v) == strings.TrimSpace(want)
+	}
+
+	return value != nil && optionID(value) == want
+}
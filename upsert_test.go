@@ -0,0 +1,96 @@
+package pipedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_UpsertPerson(t *testing.T) {
+	var updated []string
+	var created int
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/persons/search":
+			if r.URL.Query().Get("fields") != "email" || r.URL.Query().Get("exact_match") != "true" {
+				t.Errorf("Got unexpected search %s", r.URL)
+			}
+
+			switch r.URL.Query().Get("term") {
+			case "jane@example.com":
+				w.Write([]byte(`{"success": true, "data": {"items": [{"result_score": 1, "item": {"id": 1}}, {"result_score": 1, "item": {"id": 2}}]}}`))
+			case "john@example.com":
+				w.Write([]byte(`{"success": true, "data": {"items": [{"result_score": 1, "item": {"id": 3}}, {"result_score": 1, "item": {"id": 4}}]}}`))
+			default:
+				w.Write([]byte(`{"success": true, "data": {"items": []}}`))
+			}
+		case "GET /v1/persons/1":
+			w.Write([]byte(`{"success": true, "data": {"id": 1, "email": [{"value": "Jane@Example.com", "primary": true}], "update_time": "2026-01-01 00:00:00"}}`))
+		case "GET /v1/persons/2":
+			w.Write([]byte(`{"success": true, "data": {"id": 2, "email": [{"value": "jane@example.com.au", "primary": true}], "update_time": "2026-02-01 00:00:00"}}`))
+		case "GET /v1/persons/3":
+			w.Write([]byte(`{"success": true, "data": {"id": 3, "email": [{"value": "john@example.com"}], "update_time": "2026-01-01 00:00:00"}}`))
+		case "GET /v1/persons/4":
+			w.Write([]byte(`{"success": true, "data": {"id": 4, "email": [{"value": "john@example.com"}], "update_time": "2026-03-01 00:00:00"}}`))
+		case "PUT /v1/persons/1", "PUT /v1/persons/4":
+			updated = append(updated, r.URL.Path)
+			w.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		case "POST /v1/persons":
+			created++
+			w.Write([]byte(`{"success": true, "data": {"id": 5}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	result, err := client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "jane@example.com"}, &PersonCreateOptions{Name: "Jane"}, &PersonUpdateOptions{Name: "Jane"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Action != UPSERT_UPDATED || result.ID != 1 || len(result.Matches) != 1 {
+		t.Errorf("Got result %v, want person 1 updated", result)
+	}
+
+	result, err = client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "jim@example.com"}, &PersonCreateOptions{Name: "Jim"}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Action != UPSERT_CREATED || result.ID != 5 {
+		t.Errorf("Got result %v, want person 5 created", result)
+	}
+
+	_, err = client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "john@example.com"}, nil, nil)
+
+	var ambiguous *AmbiguousMatchError
+
+	if !errors.As(err, &ambiguous) || len(ambiguous.IDs) != 2 {
+		t.Errorf("Got error %v, want an ambiguous match", err)
+	}
+
+	result, err = client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "john@example.com", Policy: UPSERT_POLICY_MOST_RECENT}, nil, &PersonUpdateOptions{Name: "John"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.ID != 4 || len(updated) != 2 || updated[1] != "/v1/persons/4" {
+		t.Errorf("Got result %v, want person 4 updated", result)
+	}
+
+	if _, err := client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "jim@example.com"}, nil, &PersonUpdateOptions{Name: "Jim"}); err == nil || created != 1 {
+		t.Errorf("Got error %v and %d persons created without a create", err, created)
+	}
+
+	if _, err := client.UpsertPerson(ctx, UpsertKey{Field: "email", Value: "jane@example.com", Policy: "latest"}, nil, nil); err == nil {
+		t.Error("Got no error for an unknown policy")
+	}
+}
@@ -34,6 +34,7 @@ type User struct {
 	RoleID              int    `json:"role_id"`
 	IconURL             string `json:"icon_url"`
 	IsYou               bool   `json:"is_you"`
+	CompanyID           int    `json:"company_id"`
 }
 
 func (u User) String() string {
@@ -0,0 +1,408 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Validator checks the options of deal, person and organization creates
+// and updates against the fields of the account before they are sent, so
+// that invalid writes do not use up the rate limit. Fields, users and
+// stages are fetched once and cached; Reset drops the cache after they
+// change.
+//
+// A Validator is safe for concurrent use.
+type Validator struct {
+	client *Client
+
+	mu     sync.Mutex
+	fields map[EventObject][]EntityField
+	users  map[int]bool
+	stages map[int]Stage
+}
+
+// NewValidator returns a Validator using the client.
+func (c *Client) NewValidator() *Validator {
+	return &Validator{client: c}
+}
+
+// Reset drops the cached fields, users and stages.
+func (v *Validator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.fields, v.users, v.stages = nil, nil, nil
+}
+
+// defaultedFields are the fields which the API fills in when left out,
+// even though they are mandatory.
+var defaultedFields = map[string]bool{
+	"currency":    true,
+	"owner_id":    true,
+	"pipeline_id": true,
+	"stage_id":    true,
+	"status":      true,
+	"user_id":     true,
+	"visible_to":  true,
+}
+
+// Validate checks opt, which must be a *DealCreateOptions,
+// *DealsUpdateOptions, *PersonCreateOptions, *PersonUpdateOptions,
+// *OrganizationCreateOptions or *OrganizationUpdateOptions. It returns a
+// *ValidationError listing every problem found, or the error fetching the
+// fields, users or stages.
+//
+// Creates must fill in the mandatory fields, updates must not clear them.
+// Values must suit the type of their field: numbers for double and
+// monetary fields, dates as YYYY-MM-DD, option IDs for enum and set fields
+// and active user IDs for user fields. The stage of a deal must belong to
+// its pipeline.
+func (v *Validator) Validate(ctx context.Context, opt interface{}) error {
+	var object EventObject
+	var custom map[string]interface{}
+	create := false
+
+	switch o := opt.(type) {
+	case *DealCreateOptions:
+		object, custom, create = OBJECT_DEAL, o.CustomFields, true
+	case *DealsUpdateOptions:
+		object, custom = OBJECT_DEAL, o.CustomFields
+	case *PersonCreateOptions:
+		object, custom, create = OBJECT_PERSON, o.CustomFields, true
+	case *PersonUpdateOptions:
+		object, custom = OBJECT_PERSON, o.CustomFields
+	case *OrganizationCreateOptions:
+		object, custom, create = OBJECT_ORGANIZATION, o.CustomFields, true
+	case *OrganizationUpdateOptions:
+		object, custom = OBJECT_ORGANIZATION, o.CustomFields
+	default:
+		return fmt.Errorf("cannot validate options of type %T", opt)
+	}
+
+	values, err := recordValues(opt)
+
+	if err != nil {
+		return err
+	}
+
+	fields, err := v.loadFields(ctx, object)
+
+	if err != nil {
+		return err
+	}
+
+	byKey := map[string]EntityField{}
+
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+
+	validation := &ValidationError{Object: object}
+
+	problem := func(key, format string, args ...interface{}) {
+		validation.Problems = append(validation.Problems, ValidationProblem{
+			Field:   key,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for key, value := range values {
+		if key == "visible_to" {
+			if !validVisibleTo(value) {
+				problem(key, "%v is not a visibility, want 1, 3, 5 or 7", value)
+			}
+
+			continue
+		}
+
+		field, ok := byKey[key]
+
+		if !ok {
+			// Only custom fields are reported, the options of some
+			// built-in fields having no definition.
+			if _, isCustom := custom[key]; isCustom {
+				problem(key, "no such %v field", object)
+			}
+
+			continue
+		}
+
+		if emptyValue(value) {
+			continue
+		}
+
+		message, err := v.checkValue(ctx, field, value)
+
+		if err != nil {
+			return err
+		}
+
+		if message != "" {
+			problem(key, "%s", message)
+		}
+	}
+
+	stageID, pipelineID := refID(values["stage_id"]), refID(values["pipeline_id"])
+
+	if object == OBJECT_DEAL && (stageID != 0 || pipelineID != 0) {
+		stages, err := v.loadStages(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if stage, ok := stages[stageID]; stageID != 0 && !ok {
+			problem("stage_id", "no such stage %d", stageID)
+		} else if ok && pipelineID != 0 && stage.PipelineID != pipelineID {
+			problem("stage_id", "stage %d belongs to pipeline %d, not %d", stageID, stage.PipelineID, pipelineID)
+		} else if ok {
+			pipelineID = stage.PipelineID
+		}
+
+		if stageID == 0 && !hasPipeline(stages, pipelineID) {
+			problem("pipeline_id", "no such pipeline %d", pipelineID)
+		}
+	}
+
+	for _, field := range fields {
+		if !fieldRequired(field, stageID, pipelineID) {
+			continue
+		}
+
+		value, ok := values[field.Key]
+
+		switch {
+		case create && !ok && !defaultedFields[field.Key]:
+			problem(field.Key, "%q is mandatory", field.Name)
+		case ok && emptyValue(value):
+			problem(field.Key, "%q is mandatory and cannot be cleared", field.Name)
+		}
+	}
+
+	if len(validation.Problems) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(validation.Problems, func(i, j int) bool {
+		return validation.Problems[i].Field < validation.Problems[j].Field
+	})
+
+	return validation
+}
+
+// checkValue returns what is wrong with the value of a field, or "".
+func (v *Validator) checkValue(ctx context.Context, field EntityField, value interface{}) (string, error) {
+	switch field.FieldType {
+	case FieldTypeDouble, FieldTypeMonetary:
+		if !isNumber(value) {
+			return fmt.Sprintf("%v is not a number", value), nil
+		}
+
+	case FieldTypeDate:
+		if s, ok := value.(string); !ok || !isDate(s) {
+			return fmt.Sprintf("%v is not a date, want YYYY-MM-DD", value), nil
+		}
+
+	case FieldTypeEnum:
+		if _, ok := field.Definition().Option(optionID(value)); !ok {
+			return fmt.Sprintf("%v is not an option ID of %q", value, field.Name), nil
+		}
+
+	case FieldTypeSet:
+		definition := field.Definition()
+
+		for _, id := range setOptionIDs(value) {
+			if _, ok := definition.Option(id); !ok {
+				return fmt.Sprintf("%v is not an option ID of %q", id, field.Name), nil
+			}
+		}
+
+	case FieldTypeUser:
+		users, err := v.loadUsers(ctx)
+
+		if err != nil {
+			return "", err
+		}
+
+		if id := refID(value); !users[id] {
+			return fmt.Sprintf("%v is not an active user ID", value), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (v *Validator) loadFields(ctx context.Context, object EventObject) ([]EntityField, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if fields, ok := v.fields[object]; ok {
+		return fields, nil
+	}
+
+	api, err := v.client.Fields(object)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := api.ListFields(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if v.fields == nil {
+		v.fields = map[EventObject][]EntityField{}
+	}
+
+	v.fields[object] = fields
+
+	return fields, nil
+}
+
+func (v *Validator) loadUsers(ctx context.Context) (map[int]bool, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.users != nil {
+		return v.users, nil
+	}
+
+	response, _, err := v.client.Users.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	v.users = map[int]bool{}
+
+	for _, user := range response.Data {
+		if user.ActiveFlag {
+			v.users[user.ID] = true
+		}
+	}
+
+	return v.users, nil
+}
+
+func (v *Validator) loadStages(ctx context.Context) (map[int]Stage, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.stages != nil {
+		return v.stages, nil
+	}
+
+	response, _, err := v.client.Stages.List(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	v.stages = map[int]Stage{}
+
+	for _, stage := range response.Data {
+		v.stages[stage.ID] = stage
+	}
+
+	return v.stages, nil
+}
+
+// fieldRequired reports whether a field must be filled in. Deal fields may
+// be mandatory only in some pipelines or stages, listed by the conditions
+// of their mandatory flag.
+func fieldRequired(field EntityField, stageID, pipelineID int) bool {
+	conditions := field.MandatoryConditions
+
+	if conditions == nil {
+		return field.Mandatory()
+	}
+
+	pipelines, hasPipelines := conditions["pipeline_ids"].([]interface{})
+	stages, hasStages := conditions["stage_ids"].([]interface{})
+
+	if !hasPipelines && !hasStages {
+		return field.Mandatory()
+	}
+
+	for _, id := range pipelines {
+		if pipelineID != 0 && refID(id) == pipelineID {
+			return true
+		}
+	}
+
+	for _, id := range stages {
+		if stageID != 0 && refID(id) == stageID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasPipeline(stages map[int]Stage, pipelineID int) bool {
+	for _, stage := range stages {
+		if stage.PipelineID == pipelineID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validVisibleTo(value interface{}) bool {
+	switch VisibleTo(refID(value)) {
+	case VisibleToOwnersFollowers, VisibleToEntireCompany, VisibleToOwnersGroupSubgroups, VisibleToAllGroups:
+		return true
+	}
+
+	return false
+}
+
+// isNumber reports whether value is a number, possibly given as a string
+// or as the value of a monetary field with its currency.
+func isNumber(value interface{}) bool {
+	switch v := value.(type) {
+	case float64:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return err == nil
+	case map[string]interface{}:
+		return isNumber(v["value"])
+	}
+
+	return false
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// setOptionIDs returns the option IDs of the value of a set field, a list
+// or a string of IDs separated by commas.
+func setOptionIDs(value interface{}) []string {
+	var ids []string
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			ids = append(ids, optionID(item))
+		}
+	default:
+		for _, id := range strings.Split(optionID(v), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,109 @@
+package pipedrive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	requests := map[string]int{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/dealFields":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 1, "key": "title", "name": "Title", "field_type": "varchar", "mandatory_flag": true},
+				{"id": 2, "key": "value", "name": "Value", "field_type": "monetary", "mandatory_flag": false},
+				{"id": 3, "key": "user_id", "name": "Owner", "field_type": "user", "mandatory_flag": true},
+				{"id": 4, "key": "stage_id", "name": "Stage", "field_type": "stage", "mandatory_flag": true},
+				{"id": 5, "key": "abc", "name": "Size", "field_type": "enum", "options": [{"id": 7, "label": "S"}]},
+				{"id": 6, "key": "def", "name": "Close", "field_type": "date", "mandatory_flag": {"pipeline_ids": [2]}},
+				{"id": 7, "key": "ghi", "name": "Tags", "field_type": "set", "options": [{"id": 8, "label": "A"}, {"id": 9, "label": "B"}]},
+				{"id": 8, "key": "jkl", "name": "Score", "field_type": "double"}
+			]}`))
+		case "GET /v1/users":
+			w.Write([]byte(`{"success": true, "data": [{"id": 1, "active_flag": true}, {"id": 2, "active_flag": false}]}`))
+		case "GET /v1/stages":
+			w.Write([]byte(`{"success": true, "data": [{"id": 10, "pipeline_id": 1}, {"id": 20, "pipeline_id": 2}]}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+	validator := client.NewValidator()
+
+	err := validator.Validate(ctx, &DealCreateOptions{
+		Value:      "a lot",
+		UserID:     2,
+		PipelineID: 2,
+		StageID:    10,
+		VisibleTo:  2,
+		CustomFields: map[string]interface{}{
+			"abc": 8,
+			"def": "31/12/2021",
+			"ghi": "8,10",
+			"jkl": "1.5",
+			"xyz": 1,
+		},
+	})
+
+	var validation *ValidationError
+
+	if !errors.As(err, &validation) {
+		t.Fatalf("Got error %v, want a validation error", err)
+	}
+
+	var fields []string
+
+	for _, problem := range validation.Problems {
+		fields = append(fields, problem.Field)
+	}
+
+	want := []string{"abc", "def", "ghi", "stage_id", "title", "user_id", "value", "visible_to", "xyz"}
+
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Got problems %v, want problems with %v", validation.Problems, want)
+	}
+
+	// The date is mandatory in pipeline 2 only.
+	err = validator.Validate(ctx, &DealCreateOptions{
+		Title:        "Deal",
+		Value:        "100",
+		UserID:       1,
+		StageID:      20,
+		CustomFields: map[string]interface{}{"ghi": []interface{}{8, "9"}},
+	})
+
+	if !errors.As(err, &validation) || len(validation.Problems) != 1 || validation.Problems[0].Field != "def" {
+		t.Errorf("Got error %v, want the date missing", err)
+	}
+
+	if err := validator.Validate(ctx, &DealsUpdateOptions{StageID: 10, CustomFields: map[string]interface{}{"abc": "7"}}); err != nil {
+		t.Errorf("Got error %v validating an update", err)
+	}
+
+	if err := validator.Validate(ctx, &DealsUpdateOptions{CustomFields: map[string]interface{}{"def": nil}}); err != nil {
+		t.Errorf("Got error %v clearing a conditionally mandatory field", err)
+	}
+
+	if err := validator.Validate(ctx, &DealsUpdateOptions{CustomFields: map[string]interface{}{"title": ""}}); err == nil {
+		t.Error("Got no error clearing the title")
+	}
+
+	for _, path := range []string{"/v1/dealFields", "/v1/users", "/v1/stages"} {
+		if requests[path] != 1 {
+			t.Errorf("Got %d requests to %s, want 1", requests[path], path)
+		}
+	}
+
+	if err := validator.Validate(ctx, &NoteCreateOptions{}); err == nil {
+		t.Error("Got no error validating note options")
+	}
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Dispatcher processes events asynchronously. Its Enqueue method is a
+// HandlerFunc: passed to NewHandler, webhook requests are acknowledged as
+// soon as their event is queued, and a bounded pool of workers processes
+// the queue.
+//
+// Events whose processing fails are returned to the queue and delivered
+// again, so handlers must tolerate processing an event more than once.
+type Dispatcher struct {
+	queue   Queue
+	handle  HandlerFunc
+	workers int
+
+	// OnError, if set, is called with each event whose processing failed.
+	OnError func(event *Event, err error)
+}
+
+// NewDispatcher returns a dispatcher processing the events of queue with
+// fn, such as Router.Dispatch, using up to workers goroutines.
+func NewDispatcher(queue Queue, fn HandlerFunc, workers int) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Dispatcher{
+		queue:   queue,
+		handle:  fn,
+		workers: workers,
+	}
+}
+
+// Enqueue adds event to the queue.
+func (d *Dispatcher) Enqueue(ctx context.Context, event *Event) error {
+	return d.queue.Enqueue(ctx, event)
+}
+
+// Run processes events until ctx is done, then waits for the events being
+// processed and returns. Events are processed with a context that is not
+// canceled with ctx, so they can complete.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for i := 0; i < d.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		message, err := d.queue.Dequeue(ctx)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if d.OnError != nil {
+				d.OnError(nil, err)
+			}
+
+			// Give a failing queue time to recover.
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+
+			continue
+		}
+
+		d.process(message)
+	}
+}
+
+func (d *Dispatcher) process(message Message) {
+	ctx := context.Background()
+	event := message.Event()
+
+	err := d.handle(ctx, event)
+
+	if err != nil {
+		if d.OnError != nil {
+			d.OnError(event, err)
+		}
+
+		err = message.Nack(ctx)
+	} else {
+		err = message.Ack(ctx)
+	}
+
+	if err != nil && d.OnError != nil {
+		d.OnError(event, err)
+	}
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQueueFull is returned by Queue.Enqueue when the queue cannot hold more
+// events. The Handler answers 503 Service Unavailable so Pipedrive retries.
+var ErrQueueFull = errors.New("webhook queue is full")
+
+// ErrMaxAttempts is returned by Message.Nack when the event was delivered
+// too many times to be delivered again.
+var ErrMaxAttempts = errors.New("webhook event failed too many times")
+
+var (
+	errMissing  = errors.New("missing")
+	errTooLarge = errors.New("body too large")
+)
+
+// PayloadError occurs when a webhook payload is not valid JSON or lacks
+// required metadata. Field names the offending part of the payload.
+type PayloadError struct {
+	Field string
+	Err   error
+}
+
+func (e *PayloadError) Error() string {
+	return fmt.Sprintf("malformed webhook payload: %v: %v", e.Field, e.Err)
+}
+
+func (e *PayloadError) Unwrap() error {
+	return e.Err
+}
+
+// AuthError occurs when a webhook request lacks the expected HTTP basic
+// authentication credentials.
+type AuthError struct {
+	Username string
+}
+
+func (e *AuthError) Error() string {
+	if e.Username == "" {
+		return "webhook request is not authenticated"
+	}
+
+	return fmt.Sprintf("webhook request has invalid credentials for user %q", e.Username)
+}
+
+// PanicError occurs when a handler panics. Stack holds the stack trace of
+// the panicking goroutine.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("webhook handler panicked: %v", e.Value)
+}
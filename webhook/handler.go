@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodySize is the default size limit of webhook request bodies.
+const DefaultMaxBodySize = 10 << 20
+
+// HandlerFunc handles a webhook event. Returning an error makes Pipedrive
+// deliver the event again later.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler receiving Pipedrive webhook requests.
+//
+// Requests are answered with 401 Unauthorized when the credentials do not
+// match, 400 Bad Request when the payload is malformed, 503 Service
+// Unavailable when the HandlerFunc returns ErrQueueFull, 500 Internal Server
+// Error when it fails otherwise and 200 OK on success.
+type Handler struct {
+	username string
+	password string
+	handle   HandlerFunc
+
+	// MaxBodySize limits the size of request bodies. Defaults to
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+
+	// OnError, if set, is called with each error causing a request to fail.
+	OnError func(r *http.Request, err error)
+}
+
+// NewHandler returns a handler passing events to fn. The username and
+// password are the HTTPAuthUser and HTTPAuthPassword the webhook was
+// created with; if both are empty, requests are not authenticated.
+func NewHandler(username, password string, fn HandlerFunc) *Handler {
+	return &Handler{
+		username: username,
+		password: password,
+		handle:   fn,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.authenticate(r); err != nil {
+		h.fail(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	event, err := h.parse(r)
+
+	if err != nil {
+		status := http.StatusBadRequest
+
+		if errors.Is(err, errTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		h.fail(w, r, err, status)
+		return
+	}
+
+	if err := h.handle(r.Context(), event); err != nil {
+		status := http.StatusInternalServerError
+
+		if errors.Is(err, ErrQueueFull) {
+			status = http.StatusServiceUnavailable
+		}
+
+		h.fail(w, r, err, status)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) authenticate(r *http.Request) error {
+	if h.username == "" && h.password == "" {
+		return nil
+	}
+
+	username, password, ok := r.BasicAuth()
+
+	if !ok {
+		return &AuthError{}
+	}
+
+	userMatch := subtle.ConstantTimeCompare([]byte(username), []byte(h.username))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(h.password))
+
+	if userMatch&passwordMatch != 1 {
+		return &AuthError{Username: username}
+	}
+
+	return nil
+}
+
+func (h *Handler) parse(r *http.Request) (*Event, error) {
+	limit := h.MaxBodySize
+
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+
+	if err != nil {
+		return nil, &PayloadError{Field: "payload", Err: err}
+	}
+
+	if int64(len(body)) > limit {
+		return nil, &PayloadError{Field: "payload", Err: errTooLarge}
+	}
+
+	return Parse(body)
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error, status int) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="pipedrive"`)
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// Logging logs each handled event, how long handling took and its error.
+func Logging(logger *log.Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *Event) error {
+			start := time.Now()
+			err := next(ctx, event)
+
+			if err != nil {
+				logger.Printf("webhook %v.%v %v failed after %v: %v", event.Meta.Action, event.Meta.Object, event.Meta.ID, time.Since(start), err)
+			} else {
+				logger.Printf("webhook %v.%v %v handled in %v", event.Meta.Action, event.Meta.Object, event.Meta.ID, time.Since(start))
+			}
+
+			return err
+		}
+	}
+}
+
+// Recover turns panics of the handler into a *PanicError.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *Event) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					err = &PanicError{Value: v, Stack: debug.Stack()}
+				}
+			}()
+
+			return next(ctx, event)
+		}
+	}
+}
+
+// Retry calls the handler up to attempts times until it succeeds, waiting
+// backoff before the first retry and doubling the wait after each one.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event *Event) error {
+			wait := backoff
+
+			for attempt := 1; ; attempt++ {
+				err := next(ctx, event)
+
+				if err == nil || attempt >= attempts {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("%w (retry canceled: %v)", err, ctx.Err())
+				case <-time.After(wait):
+				}
+
+				wait *= 2
+			}
+		}
+	}
+}
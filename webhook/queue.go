@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Queue stores events between their receipt and their processing.
+//
+// Implementations deliver each event at least once: an event dequeued is
+// delivered again unless its message is acknowledged. Queues backed by
+// external storage can persist Event.Payload and rebuild events with Parse.
+type Queue interface {
+	// Enqueue stores event. Once it returns nil, the event must eventually
+	// be dequeued. ErrQueueFull signals that no more events fit.
+	Enqueue(ctx context.Context, event *Event) error
+
+	// Dequeue blocks until an event is available or ctx is done.
+	Dequeue(ctx context.Context) (Message, error)
+}
+
+// Message is an event taken from a Queue.
+type Message interface {
+	Event() *Event
+
+	// Ack removes the event from the queue after it was processed.
+	Ack(ctx context.Context) error
+
+	// Nack returns the event to the queue to be delivered again. Queues
+	// may instead drop an event delivered too many times, returning
+	// ErrMaxAttempts.
+	Nack(ctx context.Context) error
+}
+
+// MemoryQueue is a Queue keeping events in memory. Events are lost when the
+// process exits.
+type MemoryQueue struct {
+	ready chan queuedEvent
+
+	// RetryDelay is how long a negatively acknowledged event waits before
+	// it is delivered again.
+	RetryDelay time.Duration
+
+	// MaxAttempts is the number of deliveries of an event after which it is
+	// dropped rather than delivered again. It defaults to 5, and 0 retries
+	// events forever.
+	MaxAttempts int
+
+	// DeadLetter, if set, is called with the events dropped from the queue:
+	// those negatively acknowledged MaxAttempts times, with ErrMaxAttempts,
+	// and those no longer fitting in the queue when due again, with
+	// ErrQueueFull.
+	DeadLetter func(event *Event, attempts int, err error)
+
+	mu       sync.Mutex
+	inFlight int
+}
+
+type queuedEvent struct {
+	event    *Event
+	attempts int
+}
+
+// NewMemoryQueue returns a queue holding up to size events.
+func NewMemoryQueue(size int) *MemoryQueue {
+	return &MemoryQueue{
+		ready:       make(chan queuedEvent, size),
+		RetryDelay:  time.Second,
+		MaxAttempts: 5,
+	}
+}
+
+// Enqueue stores event, or returns ErrQueueFull.
+func (q *MemoryQueue) Enqueue(ctx context.Context, event *Event) error {
+	select {
+	case q.ready <- queuedEvent{event: event}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Dequeue returns the next event.
+func (q *MemoryQueue) Dequeue(ctx context.Context) (Message, error) {
+	select {
+	case queued := <-q.ready:
+		q.mu.Lock()
+		q.inFlight++
+		q.mu.Unlock()
+
+		return &memoryMessage{queue: q, event: queued.event, attempts: queued.attempts}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Len returns the number of events waiting or being processed.
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.ready) + q.inFlight
+}
+
+// settle ends the processing of an event.
+func (q *MemoryQueue) settle() {
+	q.mu.Lock()
+	q.inFlight--
+	q.mu.Unlock()
+}
+
+// drop removes an event from the queue, handing it to DeadLetter.
+func (q *MemoryQueue) drop(event *Event, attempts int, err error) {
+	q.settle()
+
+	if q.DeadLetter != nil {
+		q.DeadLetter(event, attempts, err)
+	}
+}
+
+type memoryMessage struct {
+	queue    *MemoryQueue
+	event    *Event
+	attempts int
+	once     sync.Once
+}
+
+func (m *memoryMessage) Event() *Event {
+	return m.event
+}
+
+func (m *memoryMessage) Ack(ctx context.Context) error {
+	m.once.Do(m.queue.settle)
+
+	return nil
+}
+
+// Nack returns the event to the queue after RetryDelay, or drops it with
+// ErrMaxAttempts once delivered MaxAttempts times.
+func (m *memoryMessage) Nack(ctx context.Context) error {
+	var err error
+
+	m.once.Do(func() {
+		q := m.queue
+		attempts := m.attempts + 1
+
+		if q.MaxAttempts > 0 && attempts >= q.MaxAttempts {
+			err = ErrMaxAttempts
+			q.drop(m.event, attempts, err)
+
+			return
+		}
+
+		// The event stays in flight until it is back in the queue. The
+		// queue may be full by then, in which case the event is dropped
+		// rather than waiting for room.
+		time.AfterFunc(q.RetryDelay, func() {
+			select {
+			case q.ready <- queuedEvent{event: m.event, attempts: attempts}:
+				q.settle()
+			default:
+				q.drop(m.event, attempts, ErrQueueFull)
+			}
+		})
+	})
+
+	return err
+}
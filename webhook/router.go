@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+// Middleware wraps a HandlerFunc, to add behaviour such as logging or
+// retries around it.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+type route struct {
+	object pipedrive.EventObject
+	action pipedrive.EventAction
+	handle HandlerFunc
+}
+
+func (r route) matches(meta Meta) bool {
+	return (r.object == pipedrive.OBJECT_ALL_ || r.object == meta.Object) &&
+		(r.action == pipedrive.ACTION_ALL || r.action == "*" || r.action == meta.Action)
+}
+
+// Router dispatches events to the handlers registered for their object and
+// action. Its Dispatch method is a HandlerFunc, so a Router can be passed to
+// NewHandler or NewDispatcher.
+type Router struct {
+	mu         sync.RWMutex
+	routes     []route
+	middleware []Middleware
+}
+
+// NewRouter returns an empty router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Handle registers fn for events about object with action. Use
+// pipedrive.OBJECT_ALL_ and pipedrive.ACTION_ALL to match any object or
+// action.
+func (r *Router) Handle(object pipedrive.EventObject, action pipedrive.EventAction, fn HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, route{object: object, action: action, handle: fn})
+}
+
+// Use adds middleware wrapped around every handler. The first middleware
+// added is the outermost.
+func (r *Router) Use(middleware ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Dispatch calls the handlers matching event in the order they were
+// registered, each wrapped in the middleware. All handlers run even if one
+// fails; the first error is returned. Events without handlers are ignored.
+func (r *Router) Dispatch(ctx context.Context, event *Event) error {
+	r.mu.RLock()
+	routes := r.routes
+	middleware := r.middleware
+	r.mu.RUnlock()
+
+	var firstErr error
+
+	for _, route := range routes {
+		if !route.matches(event.Meta) {
+			continue
+		}
+
+		handle := route.handle
+
+		for i := len(middleware) - 1; i >= 0; i-- {
+			handle = middleware[i](handle)
+		}
+
+		if err := handle(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
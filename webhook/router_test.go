@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+func testEvent(object pipedrive.EventObject, action pipedrive.EventAction) *Event {
+	return &Event{Meta: Meta{Object: object, Action: action, ID: 1}}
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	router := NewRouter()
+
+	var calls []string
+
+	record := func(name string) HandlerFunc {
+		return func(ctx context.Context, event *Event) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	router.Handle(pipedrive.OBJECT_DEAL, pipedrive.ACTION_UPDATED, record("deal.updated"))
+	router.Handle(pipedrive.OBJECT_DEAL, pipedrive.ACTION_ALL, record("deal.*"))
+	router.Handle(pipedrive.OBJECT_ALL_, pipedrive.ACTION_DELETED, record("*.deleted"))
+
+	router.Dispatch(context.Background(), testEvent(pipedrive.OBJECT_DEAL, pipedrive.ACTION_UPDATED))
+	router.Dispatch(context.Background(), testEvent(pipedrive.OBJECT_PERSON, pipedrive.ACTION_DELETED))
+	router.Dispatch(context.Background(), testEvent(pipedrive.OBJECT_PERSON, pipedrive.ACTION_ADDED))
+
+	want := []string{"deal.updated", "deal.*", "*.deleted"}
+
+	if len(calls) != len(want) {
+		t.Fatalf("Got calls %v, want %v", calls, want)
+	}
+
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("Got calls %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestRouter_Middleware(t *testing.T) {
+	router := NewRouter()
+	router.Use(Recover(), Retry(3, time.Millisecond))
+
+	attempts := 0
+
+	router.Handle(pipedrive.OBJECT_DEAL, pipedrive.ACTION_ADDED, func(ctx context.Context, event *Event) error {
+		attempts++
+
+		if attempts < 3 {
+			return errors.New("temporary")
+		}
+
+		return nil
+	})
+
+	router.Handle(pipedrive.OBJECT_NOTE, pipedrive.ACTION_ADDED, func(ctx context.Context, event *Event) error {
+		panic("boom")
+	})
+
+	if err := router.Dispatch(context.Background(), testEvent(pipedrive.OBJECT_DEAL, pipedrive.ACTION_ADDED)); err != nil || attempts != 3 {
+		t.Errorf("Expected success after 3 attempts, got %v after %v", err, attempts)
+	}
+
+	var panicErr *PanicError
+
+	if err := router.Dispatch(context.Background(), testEvent(pipedrive.OBJECT_NOTE, pipedrive.ACTION_ADDED)); !errors.As(err, &panicErr) {
+		t.Errorf("Expected a PanicError, got %v", err)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	queue := NewMemoryQueue(10)
+	queue.RetryDelay = time.Millisecond
+
+	var (
+		mu       sync.Mutex
+		attempts = map[int]int{}
+		done     = make(chan struct{})
+	)
+
+	dispatcher := NewDispatcher(queue, func(ctx context.Context, event *Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[event.Meta.ID]++
+
+		// The first delivery of each event fails.
+		if attempts[event.Meta.ID] == 1 {
+			return errors.New("temporary")
+		}
+
+		if len(attempts) == 5 {
+			complete := true
+
+			for _, n := range attempts {
+				complete = complete && n == 2
+			}
+
+			if complete {
+				close(done)
+			}
+		}
+
+		return nil
+	}, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+
+	go func() {
+		dispatcher.Run(ctx)
+		close(stopped)
+	}()
+
+	for id := 1; id <= 5; id++ {
+		if err := dispatcher.Enqueue(ctx, &Event{Meta: Meta{ID: id}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Events were not processed")
+	}
+
+	cancel()
+	<-stopped
+
+	if n := queue.Len(); n != 0 {
+		t.Errorf("Expected an empty queue, got %v events", n)
+	}
+}
+
+func TestMemoryQueue_Full(t *testing.T) {
+	queue := NewMemoryQueue(1)
+
+	if err := queue.Enqueue(context.Background(), &Event{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := queue.Enqueue(context.Background(), &Event{}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestMemoryQueue_DeadLetter(t *testing.T) {
+	queue := NewMemoryQueue(1)
+	queue.RetryDelay = time.Millisecond
+	queue.MaxAttempts = 2
+
+	dropped := make(chan error, 2)
+	queue.DeadLetter = func(event *Event, attempts int, err error) {
+		if attempts != event.Meta.ID {
+			t.Errorf("Got event %d dropped after %d attempts", event.Meta.ID, attempts)
+		}
+
+		dropped <- err
+	}
+
+	ctx := context.Background()
+
+	// Event 2 fails twice and is dropped.
+	if err := queue.Enqueue(ctx, &Event{Meta: Meta{ID: 2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		message, err := queue.Dequeue(ctx)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = message.Nack(ctx)
+
+		if attempt == 2 && !errors.Is(err, ErrMaxAttempts) {
+			t.Errorf("Got error %v, want ErrMaxAttempts", err)
+		}
+	}
+
+	if err := <-dropped; !errors.Is(err, ErrMaxAttempts) {
+		t.Errorf("Got event dropped with %v, want ErrMaxAttempts", err)
+	}
+
+	// Event 1 is due again once the queue filled up.
+	if err := queue.Enqueue(ctx, &Event{Meta: Meta{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := queue.Dequeue(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := queue.Enqueue(ctx, &Event{Meta: Meta{ID: 3}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := message.Nack(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-dropped:
+		if !errors.Is(err, ErrQueueFull) {
+			t.Errorf("Got event dropped with %v, want ErrQueueFull", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Event was not dropped from the full queue")
+	}
+
+	if n := queue.Len(); n != 1 {
+		t.Errorf("Got %d events, want 1", n)
+	}
+}
@@ -0,0 +1,619 @@
+// Package webhook receives Pipedrive webhook notifications.
+//
+// Pipedrive delivers events to the subscription URL of webhooks created with
+// pipedrive.WebhooksService.Create. The Handler verifies and decodes them:
+//
+//	handler := webhook.NewHandler("user", "password", func(ctx context.Context, event *webhook.Event) error {
+//		if deal, ok := event.Current.(*pipedrive.Deal); ok {
+//			fmt.Println(event.Meta.Action, deal.Title)
+//		}
+//
+//		return nil
+//	})
+//
+//	http.Handle("/pipedrive", handler)
+//
+// A Router dispatches events to handlers by object and action, and a
+// Dispatcher processes them asynchronously from a Queue:
+//
+//	router := webhook.NewRouter()
+//	router.Use(webhook.Recover(), webhook.Retry(3, time.Second))
+//	router.Handle(pipedrive.OBJECT_DEAL, pipedrive.ACTION_UPDATED, onDealUpdated)
+//
+//	dispatcher := webhook.NewDispatcher(webhook.NewMemoryQueue(1000), router.Dispatch, 8)
+//	go dispatcher.Run(ctx)
+//
+//	http.Handle("/pipedrive", webhook.NewHandler("user", "password", dispatcher.Enqueue))
+//
+// Pipedrive API docs: https://pipedrive.readme.io/docs/guide-for-webhooks
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+// Meta holds the metadata of a webhook event. Fields missing from a payload
+// version are left empty.
+type Meta struct {
+	// Version is the payload version, 1 or 2.
+	Version int
+
+	// EventID uniquely identifies the event, v2 only.
+	EventID string
+
+	Action pipedrive.EventAction
+	Object pipedrive.EventObject
+
+	// ID of the object the event is about.
+	ID int
+
+	CompanyID        int
+	UserID           int
+	WebhookID        string
+	WebhookOwnerID   int
+	Host             string
+	Timestamp        time.Time
+	Attempt          int
+	ChangeSource     string
+	IsBulkUpdate     bool
+	PermittedUserIDs []int
+
+	// CorrelationID groups events caused by the same change, v2 only.
+	CorrelationID string
+}
+
+// Event is a decoded webhook event.
+//
+// Current and Previous hold the object before and after the change, as a
+// *pipedrive.Deal, *pipedrive.Person, *pipedrive.Organization,
+// *pipedrive.Activity, *pipedrive.Note, *pipedrive.Product,
+// *pipedrive.Stage, *pipedrive.Pipeline or *pipedrive.User depending on
+// Meta.Object. Current is nil for deletions and Previous for additions.
+// In v2 payloads Previous only holds the fields that changed.
+//
+// The records of v2 payloads are converted to the shape of v1 records
+// before they are decoded: references given as IDs, such as org_id, become
+// objects holding the ID under "value", fields renamed by v2 take their v1
+// name, custom fields move to the top level and fields unknown to v1 or
+// whose value does not suit the v1 type are left out. CurrentRaw and
+// PreviousRaw keep the records as received.
+//
+// Objects of other types are not decoded, their JSON is kept in CurrentRaw
+// and PreviousRaw. Payload holds the whole payload the event was parsed
+// from.
+type Event struct {
+	Meta        Meta
+	Current     interface{}
+	Previous    interface{}
+	CurrentRaw  json.RawMessage
+	PreviousRaw json.RawMessage
+	Payload     json.RawMessage
+}
+
+// objectTypes returns an empty value of the type each object decodes into.
+var objectTypes = map[pipedrive.EventObject]func() interface{}{
+	pipedrive.OBJECT_DEAL:         func() interface{} { return &pipedrive.Deal{} },
+	pipedrive.OBJECT_PERSON:       func() interface{} { return &pipedrive.Person{} },
+	pipedrive.OBJECT_ORGANIZATION: func() interface{} { return &pipedrive.Organization{} },
+	pipedrive.OBJECT_ACTIVITY:     func() interface{} { return &pipedrive.Activity{} },
+	pipedrive.OBJECT_NOTE:         func() interface{} { return &pipedrive.Note{} },
+	pipedrive.OBJECT_PRODUCT:      func() interface{} { return &pipedrive.Product{} },
+	pipedrive.OBJECT_STAGE:        func() interface{} { return &pipedrive.Stage{} },
+	pipedrive.OBJECT_PIPELINE:     func() interface{} { return &pipedrive.Pipeline{} },
+	pipedrive.OBJECT_USER:         func() interface{} { return &pipedrive.User{} },
+}
+
+// v2Actions maps the actions of v2 payloads to event actions.
+var v2Actions = map[string]pipedrive.EventAction{
+	"create": pipedrive.ACTION_ADDED,
+	"change": pipedrive.ACTION_UPDATED,
+	"delete": pipedrive.ACTION_DELETED,
+	"merge":  pipedrive.ACTION_MERGED,
+}
+
+type envelope struct {
+	V        json.RawMessage `json:"v"`
+	Event    string          `json:"event"`
+	Meta     json.RawMessage `json:"meta"`
+	Current  json.RawMessage `json:"current"`
+	Data     json.RawMessage `json:"data"`
+	Previous json.RawMessage `json:"previous"`
+}
+
+type metaV1 struct {
+	V                int             `json:"v"`
+	Action           string          `json:"action"`
+	Object           string          `json:"object"`
+	ID               json.RawMessage `json:"id"`
+	CompanyID        int             `json:"company_id"`
+	UserID           int             `json:"user_id"`
+	WebhookID        json.RawMessage `json:"webhook_id"`
+	WebhookOwnerID   int             `json:"webhook_owner_id"`
+	Host             string          `json:"host"`
+	Timestamp        int64           `json:"timestamp"`
+	Attempt          int             `json:"attempt"`
+	ChangeSource     string          `json:"change_source"`
+	IsBulkUpdate     bool            `json:"is_bulk_update"`
+	PermittedUserIDs []int           `json:"permitted_user_ids"`
+}
+
+type metaV2 struct {
+	Version          string          `json:"version"`
+	ID               string          `json:"id"`
+	Action           string          `json:"action"`
+	Entity           string          `json:"entity"`
+	EntityID         json.RawMessage `json:"entity_id"`
+	CompanyID        json.RawMessage `json:"company_id"`
+	UserID           json.RawMessage `json:"user_id"`
+	WebhookID        json.RawMessage `json:"webhook_id"`
+	WebhookOwnerID   json.RawMessage `json:"webhook_owner_id"`
+	Host             string          `json:"host"`
+	Timestamp        time.Time       `json:"timestamp"`
+	Attempt          int             `json:"attempt"`
+	ChangeSource     string          `json:"change_source"`
+	IsBulkEdit       bool            `json:"is_bulk_edit"`
+	CorrelationID    string          `json:"correlation_id"`
+	PermittedUserIDs []json.Number   `json:"permitted_user_ids"`
+}
+
+// Parse decodes a v1 or v2 webhook payload. A *PayloadError is returned
+// if the payload is malformed.
+func Parse(payload []byte) (*Event, error) {
+	var env envelope
+
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return nil, &PayloadError{Field: "payload", Err: err}
+	}
+
+	if len(env.Meta) == 0 || isNull(env.Meta) {
+		return nil, &PayloadError{Field: "meta", Err: errMissing}
+	}
+
+	var (
+		event = &Event{Payload: payload}
+		err   error
+	)
+
+	if len(env.Data) > 0 || strings.HasPrefix(versionOf(env.Meta), "2") {
+		event.Meta, err = parseMetaV2(env.Meta)
+		event.CurrentRaw = env.Data
+	} else {
+		event.Meta, err = parseMetaV1(env.Meta, env.Event)
+		event.CurrentRaw = env.Current
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isNull(event.CurrentRaw) {
+		event.CurrentRaw = nil
+	}
+
+	if !isNull(env.Previous) {
+		event.PreviousRaw = env.Previous
+	}
+
+	newObject, ok := objectTypes[event.Meta.Object]
+
+	if !ok {
+		return event, nil
+	}
+
+	if event.Current, err = decodeObject(event.Meta, event.CurrentRaw, newObject); err != nil {
+		return nil, &PayloadError{Field: "current", Err: err}
+	}
+
+	if event.Previous, err = decodeObject(event.Meta, event.PreviousRaw, newObject); err != nil {
+		return nil, &PayloadError{Field: "previous", Err: err}
+	}
+
+	return event, nil
+}
+
+// v2Renames maps the fields of v2 records to their v1 name, by object.
+var v2Renames = map[pipedrive.EventObject]map[string]string{
+	pipedrive.OBJECT_DEAL: {
+		"owner_id": "user_id",
+	},
+	pipedrive.OBJECT_PERSON: {
+		"emails": "email",
+		"phones": "phone",
+	},
+	pipedrive.OBJECT_ACTIVITY: {
+		"owner_id": "user_id",
+	},
+}
+
+// decodeObject decodes a record of the event, nil if raw is.
+func decodeObject(meta Meta, raw json.RawMessage, newObject func() interface{}) (interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	object := newObject()
+
+	if meta.Version == 2 {
+		converted, err := v1Record(meta.Object, raw, reflect.TypeOf(object).Elem())
+
+		if err != nil {
+			return nil, err
+		}
+
+		raw = converted
+	}
+
+	if err := json.Unmarshal(raw, object); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// v1Record converts a v2 record to the shape of the v1 records decoded into
+// typ.
+func v1Record(object pipedrive.EventObject, raw json.RawMessage, typ reflect.Type) (json.RawMessage, error) {
+	var values map[string]interface{}
+
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	record := map[string]interface{}{}
+
+	if custom, ok := values["custom_fields"].(map[string]interface{}); ok {
+		for key, value := range custom {
+			v1CustomValue(record, key, value)
+		}
+	}
+
+	fields := map[string]reflect.Type{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if key := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]; key != "" && key != "-" {
+			fields[key] = typ.Field(i).Type
+		}
+	}
+
+	for key, value := range values {
+		if renamed, ok := v2Renames[object][key]; ok {
+			key = renamed
+		}
+
+		fieldType, ok := fields[key]
+
+		if !ok {
+			continue
+		}
+
+		if id, isID := value.(float64); isID && isStruct(fieldType) {
+			value = map[string]interface{}{"id": id, "value": id}
+		}
+
+		if suits(value, fieldType) {
+			record[key] = value
+		}
+	}
+
+	return json.Marshal(record)
+}
+
+// v1CustomValue sets the v1 value of a v2 custom field, which v2 sends as
+// an object holding its type and value, the option ID of enum fields or the
+// option IDs of set fields. The currency of monetary values is set under
+// the key suffixed with "_currency", as in v1.
+func v1CustomValue(record map[string]interface{}, key string, value interface{}) {
+	field, ok := value.(map[string]interface{})
+
+	if !ok {
+		record[key] = value
+		return
+	}
+
+	switch {
+	case field["value"] != nil:
+		record[key] = field["value"]
+	case field["id"] != nil:
+		record[key] = field["id"]
+	case field["ids"] != nil:
+		record[key] = field["ids"]
+	default:
+		record[key] = nil
+	}
+
+	if currency, ok := field["currency"]; ok {
+		record[key+"_currency"] = currency
+	}
+}
+
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct
+}
+
+// suits reports whether value decodes into a value of type t.
+func suits(value interface{}, t reflect.Type) bool {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(b, reflect.New(t).Interface()) == nil
+}
+
+func parseMetaV1(raw json.RawMessage, eventName string) (Meta, error) {
+	var m metaV1
+
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Meta{}, &PayloadError{Field: "meta", Err: err}
+	}
+
+	// The event field, such as "updated.deal", backs up missing metadata.
+	if parts := strings.SplitN(eventName, ".", 2); len(parts) == 2 {
+		if m.Action == "" {
+			m.Action = parts[0]
+		}
+		if m.Object == "" {
+			m.Object = parts[1]
+		}
+	}
+
+	if m.Action == "" {
+		return Meta{}, &PayloadError{Field: "meta.action", Err: errMissing}
+	}
+
+	if m.Object == "" {
+		return Meta{}, &PayloadError{Field: "meta.object", Err: errMissing}
+	}
+
+	id, err := parseID(m.ID)
+
+	if err != nil {
+		return Meta{}, &PayloadError{Field: "meta.id", Err: err}
+	}
+
+	meta := Meta{
+		Version:          1,
+		Action:           pipedrive.EventAction(m.Action),
+		Object:           pipedrive.EventObject(m.Object),
+		ID:               id,
+		CompanyID:        m.CompanyID,
+		UserID:           m.UserID,
+		WebhookID:        rawString(m.WebhookID),
+		WebhookOwnerID:   m.WebhookOwnerID,
+		Host:             m.Host,
+		Attempt:          m.Attempt,
+		ChangeSource:     m.ChangeSource,
+		IsBulkUpdate:     m.IsBulkUpdate,
+		PermittedUserIDs: m.PermittedUserIDs,
+	}
+
+	if m.Timestamp > 0 {
+		meta.Timestamp = time.Unix(m.Timestamp, 0).UTC()
+	}
+
+	return meta, nil
+}
+
+func parseMetaV2(raw json.RawMessage) (Meta, error) {
+	var m metaV2
+
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return Meta{}, &PayloadError{Field: "meta", Err: err}
+	}
+
+	action, ok := v2Actions[m.Action]
+
+	if !ok {
+		if m.Action == "" {
+			return Meta{}, &PayloadError{Field: "meta.action", Err: errMissing}
+		}
+
+		action = pipedrive.EventAction(m.Action)
+	}
+
+	if m.Entity == "" {
+		return Meta{}, &PayloadError{Field: "meta.entity", Err: errMissing}
+	}
+
+	meta := Meta{
+		Version:       2,
+		EventID:       m.ID,
+		Action:        action,
+		Object:        pipedrive.EventObject(m.Entity),
+		WebhookID:     rawString(m.WebhookID),
+		Host:          m.Host,
+		Timestamp:     m.Timestamp,
+		Attempt:       m.Attempt,
+		ChangeSource:  m.ChangeSource,
+		IsBulkUpdate:  m.IsBulkEdit,
+		CorrelationID: m.CorrelationID,
+	}
+
+	for field, value := range map[string]struct {
+		raw json.RawMessage
+		dst *int
+	}{
+		"meta.entity_id":        {m.EntityID, &meta.ID},
+		"meta.company_id":       {m.CompanyID, &meta.CompanyID},
+		"meta.user_id":          {m.UserID, &meta.UserID},
+		"meta.webhook_owner_id": {m.WebhookOwnerID, &meta.WebhookOwnerID},
+	} {
+		id, err := parseID(value.raw)
+
+		if err != nil {
+			return Meta{}, &PayloadError{Field: field, Err: err}
+		}
+
+		*value.dst = id
+	}
+
+	for _, userID := range m.PermittedUserIDs {
+		id, err := strconv.Atoi(userID.String())
+
+		if err != nil {
+			return Meta{}, &PayloadError{Field: "meta.permitted_user_ids", Err: err}
+		}
+
+		meta.PermittedUserIDs = append(meta.PermittedUserIDs, id)
+	}
+
+	return meta, nil
+}
+
+// versionOf returns the version field of v2 metadata.
+func versionOf(raw json.RawMessage) string {
+	var m struct {
+		Version string `json:"version"`
+	}
+
+	json.Unmarshal(raw, &m)
+
+	return m.Version
+}
+
+// parseID decodes an ID sent as a number or a numeric string.
+func parseID(raw json.RawMessage) (int, error) {
+	s := rawString(raw)
+
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(s)
+}
+
+// rawString returns a JSON string or number as a string.
+func rawString(raw json.RawMessage) string {
+	if isNull(raw) {
+		return ""
+	}
+
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	return string(bytes.TrimSpace(raw))
+}
+
+func isNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// Diff returns the fields changed by the event, comparing Previous to
+// Current. Field names and value types are resolved from metadata, which
+// may be nil. As v2 payloads only hold the changed fields in previous, only
+// those are compared; their custom fields are compared by key like the
+// custom fields of v1 payloads.
+func (e *Event) Diff(metadata pipedrive.FieldMetadata) (pipedrive.Changes, error) {
+	previous, err := decodeValues(e.PreviousRaw)
+
+	if err != nil {
+		return nil, &PayloadError{Field: "previous", Err: err}
+	}
+
+	current, err := decodeValues(e.CurrentRaw)
+
+	if err != nil {
+		return nil, &PayloadError{Field: "current", Err: err}
+	}
+
+	opt := &pipedrive.DiffOptions{
+		Object:   e.Meta.Object,
+		Metadata: metadata,
+	}
+
+	if e.Meta.Version == 2 && previous != nil {
+		opt.Fields = []string{}
+
+		for key := range previous {
+			opt.Fields = append(opt.Fields, key)
+		}
+	}
+
+	return pipedrive.Diff(previous, current, opt)
+}
+
+// Merge returns, for merged events, the ID of the record merged away and
+// the ID of the record it was merged with, such as for
+// pipedrive.DeletionDetector.RecordMerge. The merged record is identified
+// by the merge_what_id field of the current object, or else by the ID of
+// the previous object.
+func (e *Event) Merge() (id int, mergeWithID int, ok bool) {
+	if e.Meta.Action != pipedrive.ACTION_MERGED {
+		return 0, 0, false
+	}
+
+	current, _ := decodeValues(e.CurrentRaw)
+	previous, _ := decodeValues(e.PreviousRaw)
+
+	mergeWithID = e.Meta.ID
+
+	if mergeWithID == 0 {
+		mergeWithID = valueID(current["id"])
+	}
+
+	id = valueID(current["merge_what_id"])
+
+	if id == 0 {
+		id = valueID(previous["id"])
+	}
+
+	if id == 0 || mergeWithID == 0 || id == mergeWithID {
+		return 0, 0, false
+	}
+
+	return id, mergeWithID, true
+}
+
+// valueID returns the ID a JSON decoded value holds, or 0.
+func valueID(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		id, _ := strconv.Atoi(v)
+		return id
+	}
+
+	return 0
+}
+
+// decodeValues decodes a record into a map, moving the custom fields of v2
+// records to the top level.
+func decodeValues(raw json.RawMessage) (map[string]interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var values map[string]interface{}
+
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	if custom, ok := values["custom_fields"].(map[string]interface{}); ok {
+		delete(values, "custom_fields")
+
+		for key, value := range custom {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/polytomic/pipedrive-api"
+)
+
+const payloadV1 = `{
+	"v": 1,
+	"event": "updated.deal",
+	"meta": {
+		"v": 1,
+		"action": "updated",
+		"object": "deal",
+		"id": 42,
+		"company_id": 7,
+		"user_id": 3,
+		"host": "acme.pipedrive.com",
+		"timestamp": 1700000000,
+		"webhook_id": "123",
+		"webhook_owner_id": 3,
+		"attempt": 1,
+		"change_source": "app",
+		"permitted_user_ids": [3, 4]
+	},
+	"current": {"id": 42, "title": "Renewal", "stage_id": 2, "value": 1000, "a1b2c3": "custom"},
+	"previous": {"id": 42, "title": "Renewal", "stage_id": 1, "value": 1000, "a1b2c3": "custom"}
+}`
+
+const payloadV2 = `{
+	"data": {
+		"add_time": "2024-05-01T10:00:00Z",
+		"birthday": null,
+		"custom_fields": {
+			"d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5": {"type": "enum", "id": 31}
+		},
+		"emails": [{"label": "work", "primary": true, "value": "ada@example.com"}],
+		"first_name": "Ada",
+		"id": 9,
+		"im": [],
+		"is_deleted": false,
+		"job_title": null,
+		"label_ids": [4],
+		"last_name": "Lovelace",
+		"name": "Ada Lovelace",
+		"notes": null,
+		"org_id": 5,
+		"owner_id": 3,
+		"phones": [{"label": "mobile", "primary": true, "value": "+44 20 7946 0000"}],
+		"postal_address": {"value": null, "country": null},
+		"update_time": "2024-05-01T10:00:00Z",
+		"visible_to": "3"
+	},
+	"previous": null,
+	"meta": {
+		"action": "create",
+		"entity": "person",
+		"entity_id": "9",
+		"company_id": "7",
+		"user_id": "3",
+		"correlation_id": "c0ffee",
+		"id": "d7a4e6f0-0000-4000-8000-000000000000",
+		"is_bulk_edit": false,
+		"timestamp": "2024-05-01T10:00:00.000Z",
+		"type": "general",
+		"version": "2.0",
+		"webhook_id": "123",
+		"webhook_owner_id": "3",
+		"change_source": "app",
+		"permitted_user_ids": ["3", "4"],
+		"attempt": 2,
+		"host": "acme.pipedrive.com"
+	}
+}`
+
+const payloadV2Deal = `{
+	"data": {
+		"add_time": "2024-05-01T10:00:00Z",
+		"channel": null,
+		"channel_id": null,
+		"close_time": null,
+		"creator_user_id": 3,
+		"currency": "EUR",
+		"custom_fields": {
+			"a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2": {"type": "monetary", "value": 250, "currency": "USD"},
+			"b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3": {"type": "set", "ids": [7, 8]}
+		},
+		"expected_close_date": "2024-06-30",
+		"first_won_time": null,
+		"id": 42,
+		"is_archived": false,
+		"is_deleted": false,
+		"label_ids": [],
+		"last_activity_date": null,
+		"lost_reason": null,
+		"org_id": 5,
+		"origin": "ManuallyCreated",
+		"owner_id": 3,
+		"person_id": 9,
+		"pipeline_id": 1,
+		"probability": null,
+		"stage_change_time": "2024-05-02T08:00:00Z",
+		"stage_id": 2,
+		"status": "open",
+		"title": "Renewal",
+		"update_time": "2024-05-02T08:00:00Z",
+		"value": 1000,
+		"visible_to": "3",
+		"won_time": null
+	},
+	"previous": {
+		"stage_change_time": null,
+		"stage_id": 1,
+		"update_time": "2024-05-01T10:00:00Z"
+	},
+	"meta": {
+		"action": "change",
+		"entity": "deal",
+		"entity_id": "42",
+		"company_id": "7",
+		"user_id": "3",
+		"correlation_id": "c0ffee",
+		"id": "e8b5f7a1-0000-4000-8000-000000000000",
+		"is_bulk_edit": false,
+		"timestamp": "2024-05-02T08:00:00.000Z",
+		"type": "general",
+		"version": "2.0",
+		"webhook_id": "123",
+		"webhook_owner_id": "3",
+		"change_source": "app",
+		"permitted_user_ids": ["3"],
+		"attempt": 1,
+		"host": "acme.pipedrive.com"
+	}
+}`
+
+func TestParse_V1(t *testing.T) {
+	event, err := Parse([]byte(payloadV1))
+
+	if err != nil {
+		t.Fatalf("Could not parse payload: %v", err)
+	}
+
+	if event.Meta.Version != 1 || event.Meta.Action != pipedrive.ACTION_UPDATED || event.Meta.Object != pipedrive.OBJECT_DEAL || event.Meta.ID != 42 {
+		t.Errorf("Got invalid meta: %+v", event.Meta)
+	}
+
+	current, ok := event.Current.(*pipedrive.Deal)
+
+	if !ok || current.StageID != 2 || current.CustomFields["a1b2c3"] != "custom" {
+		t.Errorf("Got invalid current deal: %v", event.Current)
+	}
+
+	previous, ok := event.Previous.(*pipedrive.Deal)
+
+	if !ok || previous.StageID != 1 {
+		t.Errorf("Got invalid previous deal: %v", event.Previous)
+	}
+}
+
+func TestParse_V2(t *testing.T) {
+	event, err := Parse([]byte(payloadV2))
+
+	if err != nil {
+		t.Fatalf("Could not parse payload: %v", err)
+	}
+
+	if event.Meta.Version != 2 || event.Meta.Action != pipedrive.ACTION_ADDED || event.Meta.Object != pipedrive.OBJECT_PERSON || event.Meta.ID != 9 {
+		t.Errorf("Got invalid meta: %+v", event.Meta)
+	}
+
+	if event.Meta.CorrelationID != "c0ffee" || len(event.Meta.PermittedUserIDs) != 2 || event.Meta.Timestamp.IsZero() {
+		t.Errorf("Got invalid meta: %+v", event.Meta)
+	}
+
+	person, ok := event.Current.(*pipedrive.Person)
+
+	if !ok || person.Name != "Ada Lovelace" || person.OrgID == nil || person.OrgID.Value != 5 || person.OwnerID == nil || person.OwnerID.ID != 3 {
+		t.Fatalf("Got invalid current person: %v", event.Current)
+	}
+
+	if len(person.Email) != 1 || person.Email[0].Value != "ada@example.com" || len(person.Phone) != 1 || person.Phone[0].Label != "mobile" {
+		t.Errorf("Got invalid emails and phones: %v", person)
+	}
+
+	if value := person.CustomFields["d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5"]; value != 31.0 || person.CustomFields["label_ids"] != nil {
+		t.Errorf("Got invalid custom fields: %v", person.CustomFields)
+	}
+
+	if event.Previous != nil {
+		t.Errorf("Expected no previous person, got %v", event.Previous)
+	}
+}
+
+func TestParse_V2Deal(t *testing.T) {
+	event, err := Parse([]byte(payloadV2Deal))
+
+	if err != nil {
+		t.Fatalf("Could not parse payload: %v", err)
+	}
+
+	current, ok := event.Current.(*pipedrive.Deal)
+
+	if !ok || current.Title != "Renewal" || current.StageID != 2 || current.OrgID == nil || current.OrgID.Value != 5 || current.PersonID == nil || current.PersonID.Value != 9 {
+		t.Fatalf("Got invalid current deal: %v", event.Current)
+	}
+
+	if current.UserID == nil || current.UserID.ID != 3 || current.CreatorUserID == nil || current.CreatorUserID.ID != 3 {
+		t.Errorf("Got invalid owner and creator: %v", current)
+	}
+
+	custom := current.CustomFields
+
+	if custom["a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2"] != 250.0 || custom["a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2_currency"] != "USD" || len(custom["b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3"].([]interface{})) != 2 {
+		t.Errorf("Got invalid custom fields: %v", custom)
+	}
+
+	if _, ok := custom["is_archived"]; ok {
+		t.Errorf("Got v2 fields among the custom fields: %v", custom)
+	}
+
+	if previous, ok := event.Previous.(*pipedrive.Deal); !ok || previous.StageID != 1 || previous.Title != "" {
+		t.Errorf("Got invalid previous deal: %v", event.Previous)
+	}
+
+	// The handler accepts the event rather than having it redelivered.
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payloadV2Deal))
+	req.SetBasicAuth("user", "secret")
+
+	rec := httptest.NewRecorder()
+	NewHandler("user", "secret", func(ctx context.Context, event *Event) error { return nil }).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Got status %v, want %v", rec.Code, http.StatusOK)
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	for _, payload := range []string{
+		`not json`,
+		`{"current": {}}`,
+		`{"meta": {"v": 1, "object": "deal"}}`,
+		`{"meta": {"version": "2.0", "action": "change", "entity": "deal", "entity_id": "abc"}, "data": {}}`,
+		`{"meta": {"v": 1, "action": "updated", "object": "deal", "id": 1}, "current": {"id": "x"}}`,
+	} {
+		_, err := Parse([]byte(payload))
+
+		var payloadErr *PayloadError
+
+		if !errors.As(err, &payloadErr) {
+			t.Errorf("Expected a PayloadError for %s, got %v", payload, err)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var received *Event
+
+	handler := NewHandler("user", "secret", func(ctx context.Context, event *Event) error {
+		received = event
+		return nil
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		username string
+		password string
+		body     string
+		status   int
+	}{
+		{"valid", http.MethodPost, "user", "secret", payloadV1, http.StatusOK},
+		{"wrong password", http.MethodPost, "user", "wrong", payloadV1, http.StatusUnauthorized},
+		{"no credentials", http.MethodPost, "", "", payloadV1, http.StatusUnauthorized},
+		{"malformed", http.MethodPost, "user", "secret", `{}`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "user", "secret", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		received = nil
+		req := httptest.NewRequest(test.method, "/", strings.NewReader(test.body))
+
+		if test.username != "" {
+			req.SetBasicAuth(test.username, test.password)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("%v: got status %v, want %v", test.name, rec.Code, test.status)
+		}
+
+		if (received != nil) != (test.status == http.StatusOK) {
+			t.Errorf("%v: handler called: %v", test.name, received != nil)
+		}
+	}
+}
+
+func TestEvent_Diff(t *testing.T) {
+	event, err := Parse([]byte(payloadV1))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := event.Diff(nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := changes.Keys(); len(keys) != 1 || keys[0] != "stage_id" {
+		t.Errorf("Got changed fields %v", keys)
+	}
+
+	event, err = Parse([]byte(`{
+		"data": {"id": 1, "title": "Renewal", "stage_id": 2, "value": 100, "custom_fields": {"a1b2c3": {"type": "double", "value": 5}}},
+		"previous": {"stage_id": 1, "custom_fields": {"a1b2c3": {"type": "double", "value": 4}}},
+		"meta": {"action": "change", "entity": "deal", "entity_id": "1", "version": "2.0"}
+	}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err = event.Diff(nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := changes.Keys(); len(keys) != 2 || keys[0] != "a1b2c3" || keys[1] != "stage_id" {
+		t.Errorf("Got changed fields %v", keys)
+	}
+}
+
+func TestEvent_Merge(t *testing.T) {
+	event, err := Parse([]byte(`{
+		"current": {"id": 2, "name": "Jane Doe", "merge_what_id": 7},
+		"previous": {"id": 2, "name": "Jane"},
+		"meta": {"v": 1, "action": "merged", "object": "person", "id": 2}
+	}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, mergeWithID, ok := event.Merge()
+
+	if !ok || id != 7 || mergeWithID != 2 {
+		t.Errorf("Got merge of %d with %d (%v), want 7 with 2", id, mergeWithID, ok)
+	}
+
+	event, err = Parse([]byte(payloadV1))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, ok := event.Merge(); ok {
+		t.Error("Got merge for an updated event")
+	}
+}
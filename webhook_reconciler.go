@@ -0,0 +1,251 @@
+package pipedrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WebhookSubscription describes a webhook that should exist.
+type WebhookSubscription struct {
+	SubscriptionURL string
+	EventAction     EventAction
+	EventObject     EventObject
+	HTTPAuthUser    string
+
+	// HTTPAuthPassword and UserID are only used to create the webhook,
+	// they are not compared with existing webhooks.
+	HTTPAuthPassword string
+	UserID           uint
+}
+
+func (s WebhookSubscription) key() string {
+	return strings.Join([]string{s.SubscriptionURL, string(s.EventAction), string(s.EventObject), s.HTTPAuthUser}, "\x00")
+}
+
+func webhookKey(w Webhook) string {
+	user, _ := w.HTTPAuthUser.(string)
+
+	return WebhookSubscription{
+		SubscriptionURL: w.SubscriptionURL,
+		EventAction:     EventAction(w.EventAction),
+		EventObject:     EventObject(w.EventObject),
+		HTTPAuthUser:    user,
+	}.key()
+}
+
+// Healthy reports whether the last delivery of the webhook succeeded, or
+// nothing was delivered yet.
+func (w Webhook) Healthy() bool {
+	return w.LastHTTPStatus == 0 || (w.LastHTTPStatus >= 200 && w.LastHTTPStatus <= 299)
+}
+
+// WebhookPlan lists the changes needed for the webhooks of an account to
+// match the desired subscriptions.
+type WebhookPlan struct {
+	Create []WebhookSubscription `json:"create"`
+	Delete []Webhook             `json:"delete"`
+	Keep   []Webhook             `json:"keep"`
+}
+
+func (p WebhookPlan) String() string {
+	return Stringify(p)
+}
+
+// Empty reports whether the plan changes nothing.
+func (p WebhookPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Delete) == 0
+}
+
+// Unhealthy returns the kept webhooks whose last delivery failed, according
+// to their LastHTTPStatus.
+func (p WebhookPlan) Unhealthy() []Webhook {
+	var unhealthy []Webhook
+
+	for _, w := range p.Keep {
+		if !w.Healthy() {
+			unhealthy = append(unhealthy, w)
+		}
+	}
+
+	return unhealthy
+}
+
+// WebhookApplyResult reports the webhooks created and deleted applying a
+// plan.
+type WebhookApplyResult struct {
+	Created []Webhook `json:"created"`
+	Deleted []Webhook `json:"deleted"`
+}
+
+// WebhookReconcileOptions specifices the optional parameters to the
+// WebhooksService.Plan and WebhooksService.Reconcile methods.
+type WebhookReconcileOptions struct {
+	// URLPrefix limits the webhooks managed to those whose subscription URL
+	// starts with it, such as "https://example.com/pipedrive/". Other
+	// webhooks, such as those of other integrations, are never deleted. It
+	// is required, and the desired subscriptions must start with it.
+	URLPrefix string
+
+	// DryRun computes the plan without applying it.
+	DryRun bool
+}
+
+// Plan computes the changes needed for the managed webhooks to match
+// desired. Existing webhooks matching a desired subscription by URL, event
+// action, event object and auth user are kept, other managed webhooks are
+// deleted and missing subscriptions are created. Inactive webhooks and
+// duplicates are deleted and replaced. An error is returned if opt does not
+// set a URLPrefix.
+func (s *WebhooksService) Plan(ctx context.Context, desired []WebhookSubscription, opt *WebhookReconcileOptions) (*WebhookPlan, error) {
+	if opt == nil || opt.URLPrefix == "" {
+		return nil, fmt.Errorf("a URL prefix is required to reconcile webhooks")
+	}
+
+	for _, subscription := range desired {
+		if !strings.HasPrefix(subscription.SubscriptionURL, opt.URLPrefix) {
+			return nil, fmt.Errorf("subscription URL %q does not start with %q", subscription.SubscriptionURL, opt.URLPrefix)
+		}
+	}
+
+	record, _, err := s.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]Webhook{}
+	plan := &WebhookPlan{}
+
+	wanted := map[string]bool{}
+
+	for _, subscription := range desired {
+		wanted[subscription.key()] = true
+	}
+
+	for _, w := range record.Data {
+		if !strings.HasPrefix(w.SubscriptionURL, opt.URLPrefix) {
+			continue
+		}
+
+		key := webhookKey(w)
+
+		if _, duplicate := existing[key]; duplicate || !wanted[key] || w.IsActive != 1 {
+			plan.Delete = append(plan.Delete, w)
+			continue
+		}
+
+		existing[key] = w
+		plan.Keep = append(plan.Keep, w)
+	}
+
+	planned := map[string]bool{}
+
+	for _, subscription := range desired {
+		key := subscription.key()
+
+		if _, ok := existing[key]; ok || planned[key] {
+			continue
+		}
+
+		planned[key] = true
+		plan.Create = append(plan.Create, subscription)
+	}
+
+	return plan, nil
+}
+
+// Apply deletes and creates the webhooks of plan. Webhooks already deleted
+// and subscriptions already existing are skipped, so a plan can be applied
+// again after a failure.
+func (s *WebhooksService) Apply(ctx context.Context, plan *WebhookPlan) (*WebhookApplyResult, error) {
+	record, _, err := s.List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[int]bool{}
+
+	for _, w := range record.Data {
+		current[w.ID] = true
+	}
+
+	result := &WebhookApplyResult{}
+
+	for _, w := range plan.Delete {
+		if !current[w.ID] {
+			continue
+		}
+
+		_, err := s.Delete(ctx, w.ID)
+
+		var errorResponse *ErrorResponse
+
+		if errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == http.StatusNotFound {
+			err = nil
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		delete(current, w.ID)
+		result.Deleted = append(result.Deleted, w)
+	}
+
+	// Subscriptions satisfied by the remaining active webhooks.
+	active := map[string]bool{}
+
+	for _, w := range record.Data {
+		if current[w.ID] && w.IsActive == 1 {
+			active[webhookKey(w)] = true
+		}
+	}
+
+	for _, subscription := range plan.Create {
+		key := subscription.key()
+
+		if active[key] {
+			continue
+		}
+
+		created, _, err := s.Create(ctx, &WebhooksCreateOptions{
+			SubscriptionURL:  subscription.SubscriptionURL,
+			EventAction:      subscription.EventAction,
+			EventObject:      subscription.EventObject,
+			UserID:           subscription.UserID,
+			HTTPAuthUser:     subscription.HTTPAuthUser,
+			HTTPAuthPassword: subscription.HTTPAuthPassword,
+		})
+
+		if err != nil {
+			return result, err
+		}
+
+		active[key] = true
+		result.Created = append(result.Created, created.Data)
+	}
+
+	return result, nil
+}
+
+// Reconcile makes the managed webhooks match desired. It returns the plan
+// and, unless opt.DryRun is set, the result of applying it.
+func (s *WebhooksService) Reconcile(ctx context.Context, desired []WebhookSubscription, opt *WebhookReconcileOptions) (*WebhookPlan, *WebhookApplyResult, error) {
+	plan, err := s.Plan(ctx, desired, opt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opt != nil && opt.DryRun {
+		return plan, nil, nil
+	}
+
+	result, err := s.Apply(ctx, plan)
+
+	return plan, result, err
+}
@@ -0,0 +1,111 @@
+package pipedrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWebhooksService_Reconcile(t *testing.T) {
+	var deleted []string
+	var created []map[string]interface{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/webhooks":
+			w.Write([]byte(`{"success": true, "data": [
+				{"id": 1, "subscription_url": "https://h/a", "event_action": "added", "event_object": "deal", "http_auth_user": "u", "is_active": 1, "last_http_status": 500},
+				{"id": 2, "subscription_url": "https://h/a", "event_action": "added", "event_object": "deal", "http_auth_user": "u", "is_active": 1},
+				{"id": 3, "subscription_url": "https://h/b", "event_action": "updated", "event_object": "person", "is_active": 0},
+				{"id": 4, "subscription_url": "https://h/old", "event_action": "deleted", "event_object": "deal", "is_active": 1},
+				{"id": 5, "subscription_url": "https://other/x", "event_action": "*", "event_object": "*", "is_active": 1}
+			]}`))
+		case "DELETE /v1/webhooks/2", "DELETE /v1/webhooks/3":
+			deleted = append(deleted, r.URL.Path)
+			w.Write([]byte(`{"success": true, "data": {"id": 2}}`))
+		case "DELETE /v1/webhooks/4":
+			// Deleted since the plan was computed.
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "error": "Webhook not found"}`))
+		case "POST /v1/webhooks":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+
+			w.Write([]byte(`{"success": true, "data": {"id": 10, "is_active": 1}}`))
+		default:
+			t.Errorf("Got unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	desired := []WebhookSubscription{
+		{SubscriptionURL: "https://h/a", EventAction: ACTION_ADDED, EventObject: OBJECT_DEAL, HTTPAuthUser: "u", HTTPAuthPassword: "p"},
+		{SubscriptionURL: "https://h/b", EventAction: ACTION_UPDATED, EventObject: OBJECT_PERSON},
+		{SubscriptionURL: "https://h/c", EventAction: ACTION_ADDED, EventObject: OBJECT_NOTE},
+		{SubscriptionURL: "https://h/c", EventAction: ACTION_ADDED, EventObject: OBJECT_NOTE},
+	}
+
+	opt := &WebhookReconcileOptions{URLPrefix: "https://h/", DryRun: true}
+
+	plan, result, err := client.Webhooks.Reconcile(ctx, desired, opt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != nil || len(deleted) != 0 || len(created) != 0 {
+		t.Errorf("Got result %v applying a dry run", result)
+	}
+
+	var keep, remove []int
+
+	for _, w := range plan.Keep {
+		keep = append(keep, w.ID)
+	}
+
+	for _, w := range plan.Delete {
+		remove = append(remove, w.ID)
+	}
+
+	if !reflect.DeepEqual(keep, []int{1}) || !reflect.DeepEqual(remove, []int{2, 3, 4}) || len(plan.Create) != 2 {
+		t.Errorf("Got plan %v", plan)
+	}
+
+	if unhealthy := plan.Unhealthy(); len(unhealthy) != 1 || unhealthy[0].ID != 1 {
+		t.Errorf("Got unhealthy webhooks %v", unhealthy)
+	}
+
+	opt.DryRun = false
+
+	_, result, err = client.Webhooks.Reconcile(ctx, desired, opt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Deleted) != 3 || len(result.Created) != 2 || !reflect.DeepEqual(deleted, []string{"/v1/webhooks/2", "/v1/webhooks/3"}) {
+		t.Errorf("Got result %v, deleted %v", result, deleted)
+	}
+
+	if len(created) != 2 || created[0]["subscription_url"] != "https://h/b" || created[1]["subscription_url"] != "https://h/c" {
+		t.Errorf("Got webhooks created %v", created)
+	}
+
+	// The webhook of another integration, 5, is left alone.
+	for _, w := range result.Deleted {
+		if w.ID == 5 {
+			t.Errorf("Got the webhook of another integration deleted")
+		}
+	}
+
+	for _, opt := range []*WebhookReconcileOptions{nil, {DryRun: true}, {URLPrefix: "https://other/", DryRun: true}} {
+		if _, _, err := client.Webhooks.Reconcile(ctx, desired, opt); err == nil {
+			t.Errorf("Got no error reconciling with options %v", opt)
+		}
+	}
+}
@@ -74,14 +74,16 @@ func (s *WebhooksService) List(ctx context.Context) (*WebhooksResponse, *Respons
 }
 
 // WebhooksCreateOptions specifices the optional parameters to the
-// WebhooksService.Create method.
+// WebhooksService.Create method. SubscriptionURL, EventAction and
+// EventObject are required.
 type WebhooksCreateOptions struct {
-	SubscriptionURL  string      `url:"subscription_url"`
-	EventAction      EventAction `url:"event_action"`
-	DealProbability  EventObject `url:"event_object"`
-	UserID           uint        `url:"user_id"`
-	HTTPAuthUser     string      `url:"http_auth_user"`
-	HTTPAuthPassword string      `url:"http_auth_password"`
+	SubscriptionURL  string      `json:"subscription_url"`
+	EventAction      EventAction `json:"event_action"`
+	EventObject      EventObject `json:"event_object"`
+	UserID           uint        `json:"user_id,omitempty"`
+	HTTPAuthUser     string      `json:"http_auth_user,omitempty"`
+	HTTPAuthPassword string      `json:"http_auth_password,omitempty"`
+	Version          string      `json:"version,omitempty"`
 }
 
 // Create a webhook.